@@ -0,0 +1,83 @@
+package nexus
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// AnonymousAccess represents the anonymous access settings of Nexus. When enabled,
+// requests without credentials are made as UserID from the realm RealmName,
+// which default to "anonymous" and RealmLocalAuthorizing.
+type AnonymousAccess struct {
+	Enabled   *bool   `json:"enabled"`
+	UserID    *string `json:"userId,omitempty"`
+	RealmName *string `json:"realmName,omitempty"`
+}
+
+// GetAnonymousAccess returns the current anonymous access settings
+func (n *Nexus) GetAnonymousAccess() (res *AnonymousAccess, err error) {
+	return n.GetAnonymousAccessWithContext(context.Background())
+}
+
+// GetAnonymousAccessWithContext is the same as GetAnonymousAccess with the addition of a context
+func (n *Nexus) GetAnonymousAccessWithContext(ctx context.Context) (res *AnonymousAccess, err error) {
+	req, err := n.NewRequestWithContext(ctx, "GET", "service/rest/v1/security/anonymous", nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: "Insufficient permissions to get anonymous access settings",
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// SetAnonymousAccess updates the anonymous access settings and returns the result.
+// Fields left nil keep their current value.
+//
+// Example
+//
+// Disable anonymous access
+//
+//     _, err := client.SetAnonymousAccess(&nexus.AnonymousAccess{
+//         Enabled: nexus.Bool(false),
+//     })
+func (n *Nexus) SetAnonymousAccess(settings *AnonymousAccess) (res *AnonymousAccess, err error) {
+	return n.SetAnonymousAccessWithContext(context.Background(), settings)
+}
+
+// SetAnonymousAccessWithContext is the same as SetAnonymousAccess with the addition of a context
+func (n *Nexus) SetAnonymousAccessWithContext(ctx context.Context, settings *AnonymousAccess) (res *AnonymousAccess, err error) {
+	current, err := n.GetAnonymousAccessWithContext(ctx)
+	if err != nil {
+		return
+	}
+	if settings.Enabled != nil {
+		current.Enabled = settings.Enabled
+	}
+	if settings.UserID != nil {
+		current.UserID = settings.UserID
+	}
+	if settings.RealmName != nil {
+		current.RealmName = settings.RealmName
+	}
+	payload, err := json.Marshal(current)
+	if err != nil {
+		return
+	}
+	req, err := n.NewRequestWithContext(ctx, "PUT", "service/rest/v1/security/anonymous", nil, payload, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: "Insufficient permissions to set anonymous access settings",
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
@@ -1,11 +1,12 @@
 package nexus
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
-	"strings"
 )
 
 // Asset represents an asset in Nexus and it's associated metadata
@@ -22,10 +23,18 @@ type Asset struct {
 
 // Delete this asset instance from Nexus
 func (a *Asset) Delete() (err error) {
-	return a.client.DeleteAsset(&DeleteAssetInput{ID: a.ID})
+	return a.DeleteWithContext(context.Background())
 }
 
-// Download this asset, returns a bytes representation of the object
+// DeleteWithContext is the same as Delete with the addition of a context
+func (a *Asset) DeleteWithContext(ctx context.Context) (err error) {
+	return a.client.DeleteAssetWithContext(ctx, &DeleteAssetInput{ID: a.ID})
+}
+
+// Download this asset, returns a bytes representation of the object. The whole
+// object is held in memory, for large assets use DownloadTo or Open instead. Like
+// DownloadTo, the content is verified against the checksums Nexus reported for the
+// asset and a *ChecksumError is returned on a mismatch.
 //
 // Example 1
 //
@@ -66,12 +75,17 @@ func (a *Asset) Delete() (err error) {
 //   })
 //
 func (a *Asset) Download() (data []byte, err error) {
-	endpoint := strings.Replace(*a.DownloadURL, a.client.host, "", 1)
-	req, err := a.client.NewRequest("GET", endpoint, nil, nil, "")
-	if err != nil {
+	return a.DownloadWithContext(context.Background())
+}
+
+// DownloadWithContext is the same as Download with the addition of a context.
+// Cancelling the context aborts the transfer.
+func (a *Asset) DownloadWithContext(ctx context.Context) (data []byte, err error) {
+	var buf bytes.Buffer
+	if _, err = a.DownloadTo(ctx, &buf); err != nil {
 		return
 	}
-	data, err = a.client.Do(req, nil, false)
+	data = buf.Bytes()
 	return
 }
 
@@ -97,36 +111,42 @@ type ListAssetsResponse struct {
 	ContinuationToken *string  `json:"continuationToken"`
 }
 
-func (n *Nexus) newListAssetsReq(input *ListAssetsInput) (req *http.Request, err error) {
+func (n *Nexus) newListAssetsReq(ctx context.Context, input *ListAssetsInput) (req *http.Request, err error) {
 	args := map[string]string{
 		"repository": *input.Repository,
 	}
 	if input.ContinuationToken != nil {
 		args["continuationToken"] = *input.ContinuationToken
 	}
-	req, err = n.NewRequest("GET", "service/rest/v1/assets", args, nil, "")
+	req, err = n.NewRequestWithContext(ctx, "GET", "service/rest/v1/assets", args, nil, "")
 	return
 }
 
-func (n *Nexus) newGetAssetReq(input *GetAssetInput) (req *http.Request, err error) {
+func (n *Nexus) newGetAssetReq(ctx context.Context, input *GetAssetInput) (req *http.Request, err error) {
 	endpoint := fmt.Sprintf("service/rest/v1/assets/%s", *input.ID)
-	req, err = n.NewRequest("GET", endpoint, nil, nil, "")
+	req, err = n.NewRequestWithContext(ctx, "GET", endpoint, nil, nil, "")
 	return
 }
 
-func (n *Nexus) newDeleteAssetReq(input *DeleteAssetInput) (req *http.Request, err error) {
+func (n *Nexus) newDeleteAssetReq(ctx context.Context, input *DeleteAssetInput) (req *http.Request, err error) {
 	endpoint := fmt.Sprintf("service/rest/v1/assets/%s", *input.ID)
-	req, err = n.NewRequest("DELETE", endpoint, nil, nil, "")
+	req, err = n.NewRequestWithContext(ctx, "DELETE", endpoint, nil, nil, "")
 	return
 }
 
 // ListAssets returns a response with up to 10 assets and a token to request the next page.
 func (n *Nexus) ListAssets(input *ListAssetsInput) (res *ListAssetsResponse, err error) {
+	return n.ListAssetsWithContext(context.Background(), input)
+}
+
+// ListAssetsWithContext is the same as ListAssets with the addition of a context
+// for cancellation and deadlines.
+func (n *Nexus) ListAssetsWithContext(ctx context.Context, input *ListAssetsInput) (res *ListAssetsResponse, err error) {
 	if input.Repository == nil {
 		err = errors.New("Repository is required for ListAssets")
 		return
 	}
-	req, err := n.newListAssetsReq(input)
+	req, err := n.newListAssetsReq(ctx, input)
 	if err != nil {
 		return
 	}
@@ -172,7 +192,16 @@ func (n *Nexus) ListAssets(input *ListAssetsInput) (res *ListAssetsResponse, err
 //     log.Fatal(err)
 //   }
 func (n *Nexus) ListAssetsPages(input *ListAssetsInput, cb func(res *ListAssetsResponse, last bool) (cont bool, err error)) error {
-	res, err := n.ListAssets(input)
+	return n.ListAssetsPagesWithContext(context.Background(), input, cb)
+}
+
+// ListAssetsPagesWithContext is the same as ListAssetsPages with the addition of a context.
+// Iteration stops and the context's error is returned once it is cancelled.
+func (n *Nexus) ListAssetsPagesWithContext(ctx context.Context, input *ListAssetsInput, cb func(res *ListAssetsResponse, last bool) (cont bool, err error)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	res, err := n.ListAssetsWithContext(ctx, input)
 	if err != nil {
 		return err
 	}
@@ -191,16 +220,21 @@ func (n *Nexus) ListAssetsPages(input *ListAssetsInput, cb func(res *ListAssetsR
 		Repository:        input.Repository,
 		ContinuationToken: res.ContinuationToken,
 	}
-	return n.ListAssetsPages(newInput, cb)
+	return n.ListAssetsPagesWithContext(ctx, newInput, cb)
 }
 
 // GetAsset retrieves an asset by the given ID.
 func (n *Nexus) GetAsset(input *GetAssetInput) (res *Asset, err error) {
+	return n.GetAssetWithContext(context.Background(), input)
+}
+
+// GetAssetWithContext is the same as GetAsset with the addition of a context
+func (n *Nexus) GetAssetWithContext(ctx context.Context, input *GetAssetInput) (res *Asset, err error) {
 	if input.ID == nil {
 		err = errors.New("Asset ID is required for GetAsset")
 		return
 	}
-	req, err := n.newGetAssetReq(input)
+	req, err := n.newGetAssetReq(ctx, input)
 	if err != nil {
 		return
 	}
@@ -222,11 +256,16 @@ func (n *Nexus) GetAsset(input *GetAssetInput) (res *Asset, err error) {
 
 // DeleteAsset removes an asset with the given ID.
 func (n *Nexus) DeleteAsset(input *DeleteAssetInput) (err error) {
+	return n.DeleteAssetWithContext(context.Background(), input)
+}
+
+// DeleteAssetWithContext is the same as DeleteAsset with the addition of a context
+func (n *Nexus) DeleteAssetWithContext(ctx context.Context, input *DeleteAssetInput) (err error) {
 	if input.ID == nil {
 		err = errors.New("Asset ID is required for DeleteAsset")
 		return
 	}
-	req, err := n.newDeleteAssetReq(input)
+	req, err := n.newDeleteAssetReq(ctx, input)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,48 @@
+package nexus
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAssetDownloadVerifiesChecksum(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	}))
+	defer srv.Close()
+	client, err := NewWithOptions(srv.URL, WithoutStatusCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		checksum map[string]string
+		mismatch bool
+	}{
+		{nil, false},
+		{map[string]string{"sha1": helloSHA1}, false},
+		{map[string]string{"sha1": "0000000000000000000000000000000000000000"}, true},
+		{map[string]string{"md5": "00000000000000000000000000000000"}, true},
+	} {
+		asset := &Asset{
+			DownloadURL: String(srv.URL + "/repository/raw/hello.txt"),
+			Path:        String("hello.txt"),
+			client:      client,
+		}
+		if tc.checksum != nil {
+			asset.Checksum = &tc.checksum
+		}
+		data, err := asset.Download()
+		var checksumErr *ChecksumError
+		if tc.mismatch {
+			if !errors.As(err, &checksumErr) || !errors.Is(err, ErrChecksumMismatch) {
+				t.Errorf("Checksum %v: expected a *ChecksumError, got %v", tc.checksum, err)
+			}
+			continue
+		}
+		if err != nil || string(data) != "hello" {
+			t.Errorf("Checksum %v: unexpected result %q, %v", tc.checksum, data, err)
+		}
+	}
+}
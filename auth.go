@@ -0,0 +1,230 @@
+package nexus
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Authenticator applies credentials to each request made to Nexus. The
+// implementations in this package cover basic authentication, user tokens,
+// header based tokens, anonymous access, netrc files and environment variables.
+// Custom schemes can be provided with AuthenticatorFunc.
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// AuthenticatorFunc is an adapter to allow the use of an ordinary function as an Authenticator
+type AuthenticatorFunc func(req *http.Request) error
+
+// Authenticate calls f(req)
+func (f AuthenticatorFunc) Authenticate(req *http.Request) error {
+	return f(req)
+}
+
+// Environment variables read by EnvAuth
+const (
+	EnvUsername    = "NEXUS_USERNAME"
+	EnvPassword    = "NEXUS_PASSWORD"
+	EnvNameCode    = "NEXUS_NAME_CODE"
+	EnvPassCode    = "NEXUS_PASS_CODE"
+	EnvBearerToken = "NEXUS_BEARER_TOKEN"
+)
+
+type basicAuth struct {
+	username string
+	password string
+}
+
+func (a *basicAuth) Authenticate(req *http.Request) error {
+	req.SetBasicAuth(a.username, a.password)
+	return nil
+}
+
+type headerAuth struct {
+	header string
+	value  string
+}
+
+func (a *headerAuth) Authenticate(req *http.Request) error {
+	req.Header.Set(a.header, a.value)
+	return nil
+}
+
+type anonymousAuth struct{}
+
+func (a anonymousAuth) Authenticate(req *http.Request) error {
+	return nil
+}
+
+// BasicAuth authenticates with the username and password of a Nexus account
+func BasicAuth(username string, password string) Authenticator {
+	return &basicAuth{username: username, password: password}
+}
+
+// UserTokenAuth authenticates with a user token, so the password of the account
+// is never used. The name code and pass code are shown in the user token section
+// of the user's account page. User tokens require Nexus Repository Pro and the
+// user token realm to be active.
+func UserTokenAuth(nameCode string, passCode string) Authenticator {
+	return &basicAuth{username: nameCode, password: passCode}
+}
+
+// HeaderAuth sets the given header to value on every request. This is useful when
+// Nexus sits behind a reverse proxy that authenticates users from a header and
+// passes the remote user on to Nexus.
+func HeaderAuth(header string, value string) Authenticator {
+	return &headerAuth{header: header, value: value}
+}
+
+// BearerTokenAuth sends the given token in an Authorization header with the
+// Bearer scheme, as expected by most SSO reverse proxies.
+func BearerTokenAuth(token string) Authenticator {
+	return HeaderAuth("Authorization", "Bearer "+token)
+}
+
+// AnonymousAuth sends no credentials, so requests are made as the anonymous user.
+// Anonymous access must be enabled in Nexus.
+func AnonymousAuth() Authenticator {
+	return anonymousAuth{}
+}
+
+// EnvAuth returns an Authenticator for the credentials found in the environment.
+// A user token in NEXUS_NAME_CODE and NEXUS_PASS_CODE is preferred, followed by
+// a token in NEXUS_BEARER_TOKEN and finally a username and password in
+// NEXUS_USERNAME and NEXUS_PASSWORD. An error is returned when none are set.
+func EnvAuth() (Authenticator, error) {
+	if nameCode, passCode := os.Getenv(EnvNameCode), os.Getenv(EnvPassCode); nameCode != "" && passCode != "" {
+		return UserTokenAuth(nameCode, passCode), nil
+	}
+	if token := os.Getenv(EnvBearerToken); token != "" {
+		return BearerTokenAuth(token), nil
+	}
+	if username := os.Getenv(EnvUsername); username != "" {
+		return BasicAuth(username, os.Getenv(EnvPassword)), nil
+	}
+	return nil, fmt.Errorf("No credentials found in the environment, set %s and %s, %s, or %s and %s",
+		EnvNameCode, EnvPassCode, EnvBearerToken, EnvUsername, EnvPassword)
+}
+
+type netrcAuth struct {
+	path     string
+	machines map[string]*basicAuth
+	fallback *basicAuth
+}
+
+func (a *netrcAuth) Authenticate(req *http.Request) error {
+	if creds, ok := a.machines[req.URL.Hostname()]; ok {
+		return creds.Authenticate(req)
+	}
+	if a.fallback != nil {
+		return a.fallback.Authenticate(req)
+	}
+	return fmt.Errorf("No credentials for %s found in %s", req.URL.Hostname(), a.path)
+}
+
+// NetrcAuth returns an Authenticator that uses the login and password of the
+// machine entry matching the host of each request in the given netrc file. When
+// path is empty, the file named by the NETRC environment variable or ~/.netrc is
+// used. A default entry, if present, is used for hosts without a machine entry.
+//
+// Example
+//
+// Create a client that reads its credentials from ~/.netrc
+//
+//     auth, err := nexus.NetrcAuth("")
+//     if err != nil {
+//         log.Fatal(err)
+//     }
+//     client, err := nexus.NewWithOptions("https://nexus.example.com",
+//         nexus.WithAuthenticator(auth),
+//     )
+func NetrcAuth(path string) (Authenticator, error) {
+	if path == "" {
+		path = os.Getenv("NETRC")
+	}
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, ".netrc")
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	auth := &netrcAuth{path: path, machines: make(map[string]*basicAuth)}
+	if err := auth.parse(string(data)); err != nil {
+		return nil, err
+	}
+	return auth, nil
+}
+
+// parse reads the machine and default entries of a netrc file. Comments and
+// macro definitions are skipped.
+func (a *netrcAuth) parse(data string) error {
+	tokens := make([]string, 0)
+	lines := strings.Split(data, "\n")
+	for i := 0; i < len(lines); i++ {
+		for _, field := range strings.Fields(lines[i]) {
+			if strings.HasPrefix(field, "#") {
+				break
+			}
+			tokens = append(tokens, field)
+			if field == "macdef" {
+				// a macro continues until the next empty line
+				for i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+					i++
+				}
+				break
+			}
+		}
+	}
+	var current *basicAuth
+	for i := 0; i < len(tokens); i++ {
+		keyword := tokens[i]
+		switch keyword {
+		case "default":
+			current = &basicAuth{}
+			if a.fallback == nil {
+				a.fallback = current
+			}
+			continue
+		case "macdef":
+			// the macro name was skipped with its body
+			continue
+		case "machine", "login", "password", "account":
+		default:
+			return fmt.Errorf("Unexpected %q in %s", keyword, a.path)
+		}
+		if i+1 >= len(tokens) {
+			return fmt.Errorf("Missing value for %s in %s", keyword, a.path)
+		}
+		i++
+		value := tokens[i]
+		if keyword == "machine" {
+			current = &basicAuth{}
+			if _, ok := a.machines[value]; !ok {
+				a.machines[value] = current
+			}
+			continue
+		}
+		if current == nil {
+			return fmt.Errorf("Found %s outside of a machine entry in %s", keyword, a.path)
+		}
+		switch keyword {
+		case "login":
+			current.username = value
+		case "password":
+			current.password = value
+		}
+	}
+	if len(a.machines) == 0 && a.fallback == nil {
+		return fmt.Errorf("No machine entries found in %s", a.path)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package nexus
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNetrcParse(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		netrc string
+		// expected maps hosts to "username:password", or to "" when no credentials apply
+		expected map[string]string
+		fails    bool
+	}{
+		{
+			name:     "machine",
+			netrc:    "machine nexus.example.com login alice password secret\n",
+			expected: map[string]string{"nexus.example.com": "alice:secret", "other.example.com": ""},
+		},
+		{
+			name:     "multiple lines",
+			netrc:    "machine nexus.example.com\n  login alice\n  password secret\n",
+			expected: map[string]string{"nexus.example.com": "alice:secret"},
+		},
+		{
+			name:     "default",
+			netrc:    "machine nexus.example.com login alice password secret\ndefault login anonymous password guest\n",
+			expected: map[string]string{"nexus.example.com": "alice:secret", "other.example.com": "anonymous:guest"},
+		},
+		{
+			name:     "comments",
+			netrc:    "# credentials for nexus\nmachine nexus.example.com login alice # the ci user\n password secret\n",
+			expected: map[string]string{"nexus.example.com": "alice:secret"},
+		},
+		{
+			name:     "macdef",
+			netrc:    "machine nexus.example.com login alice password secret\nmacdef init\nmachine evil.example.com login mallory password x\n\nmachine other.example.com login bob password hunter2\n",
+			expected: map[string]string{"nexus.example.com": "alice:secret", "other.example.com": "bob:hunter2", "evil.example.com": ""},
+		},
+		{
+			name:     "account",
+			netrc:    "machine nexus.example.com login alice account ops password secret\n",
+			expected: map[string]string{"nexus.example.com": "alice:secret"},
+		},
+		{
+			name:     "first machine entry wins",
+			netrc:    "machine nexus.example.com login alice password secret\nmachine nexus.example.com login bob password hunter2\n",
+			expected: map[string]string{"nexus.example.com": "alice:secret"},
+		},
+		{name: "login outside of a machine", netrc: "login alice password secret\n", fails: true},
+		{name: "missing value", netrc: "machine nexus.example.com login", fails: true},
+		{name: "unknown keyword", netrc: "machine nexus.example.com user alice\n", fails: true},
+		{name: "empty", netrc: "# nothing here\n", fails: true},
+	} {
+		auth := &netrcAuth{path: ".netrc", machines: make(map[string]*basicAuth)}
+		err := auth.parse(tc.netrc)
+		if tc.fails {
+			if err == nil {
+				t.Errorf("%s: expected an error", tc.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		for host, expected := range tc.expected {
+			req, _ := http.NewRequest("GET", "https://"+host+"/service/rest/v1/status", nil)
+			err := auth.Authenticate(req)
+			username, password, ok := req.BasicAuth()
+			switch {
+			case expected == "" && err == nil:
+				t.Errorf("%s: expected no credentials for %s, got %s:%s", tc.name, host, username, password)
+			case expected != "" && (err != nil || !ok || username+":"+password != expected):
+				t.Errorf("%s: expected %s for %s, got %s:%s (%v)", tc.name, expected, host, username, password, err)
+			}
+		}
+	}
+}
+
+func TestNetrcAuthReadsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "netrc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, ".netrc")
+	if err := ioutil.WriteFile(path, []byte("machine localhost login alice password secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	auth, err := NetrcAuth(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest("GET", "http://localhost:8081/service/rest/v1/status", nil)
+	if err := auth.Authenticate(req); err != nil {
+		t.Fatal(err)
+	}
+	if username, password, _ := req.BasicAuth(); username != "alice" || password != "secret" {
+		t.Errorf("Unexpected credentials %s:%s", username, password)
+	}
+	if _, err := NetrcAuth(filepath.Join(dir, "missing")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
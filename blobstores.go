@@ -1,7 +1,9 @@
 package nexus
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
@@ -11,6 +13,44 @@ var BlobStoreTypeFile = String("File")
 // BlobStoreTypeS3 is used for creating S3-backed blob stores
 var BlobStoreTypeS3 = String("S3")
 
+// BlobStoreTypeGroup is used for creating blob stores that group other blob stores
+var BlobStoreTypeGroup = String("Group")
+
+// BlobStoreTypeAzure is used for creating blob stores in Azure Blob Storage
+var BlobStoreTypeAzure = String("Azure Cloud Storage")
+
+// BlobStoreTypeGoogle is used for creating blob stores in Google Cloud Storage
+var BlobStoreTypeGoogle = String("Google Cloud Storage")
+
+// AzureAuthenticationMethodAccountKey and friends are used when specifying
+// AzureBlobStoreConfig.AuthenticationMethod
+var (
+	AzureAuthenticationMethodAccountKey          = String("ACCOUNTKEY")
+	AzureAuthenticationMethodManagedIdentity     = String("MANAGEDIDENTITY")
+	AzureAuthenticationMethodEnvironmentVariable = String("ENVIRONMENTVARIABLE")
+)
+
+// S3EncryptionTypeS3Managed and S3EncryptionTypeKMS are used when specifying
+// S3BlobStoreConfig.EncryptionType
+var (
+	S3EncryptionTypeS3Managed = String("s3ManagedEncryption")
+	S3EncryptionTypeKMS       = String("kmsManagedEncryption")
+)
+
+// BlobStoreFillPolicyRoundRobin and BlobStoreFillPolicyWriteToFirst are used when
+// specifying GroupBlobStoreConfig.FillPolicy
+var (
+	BlobStoreFillPolicyRoundRobin   = String("roundRobin")
+	BlobStoreFillPolicyWriteToFirst = String("writeToFirst")
+)
+
+// BlobStoreQuotaTypeSpaceRemaining and BlobStoreQuotaTypeSpaceUsed are used when
+// specifying BlobStoreSoftQuota.Type
+var (
+	BlobStoreQuotaTypeSpaceRemaining = String("spaceRemainingQuota")
+	BlobStoreQuotaTypeSpaceUsed      = String("spaceUsedQuota")
+)
+
 var createBlobStoreScriptName = String("nexus3-go-create-blobstore")
 var createBlobStoreScript = String(`
 import groovy.json.JsonSlurper
@@ -19,7 +59,15 @@ parsed_args = new JsonSlurper().parseText(args)
 existingBlobStore = blobStore.getBlobStoreManager().get(parsed_args.name)
 if (existingBlobStore == null) {
   if (parsed_args.type == "S3") {
-      blobStore.createS3BlobStore(parsed_args.name, parsed_args.config)
+      config = parsed_args.config.findAll { k, v -> v != null }
+      // translate to the attribute names used by the S3 blob store
+      [signerType: "signertype", forcePathStyle: "forcepathstyle", encryptionType: "encryption_type",
+       encryptionKey: "encryption_key", maxConnectionPoolSize: "max_connection_pool_size"].each { from, to ->
+          if (config.containsKey(from)) {
+              config[to] = config.remove(from).toString()
+          }
+      }
+      blobStore.createS3BlobStore(parsed_args.name, config)
       msg = "created"
   } else {
       blobStore.createFileBlobStore(parsed_args.name, parsed_args.path)
@@ -51,6 +99,19 @@ if (existingBlobStore != null) {
 return msg
 `)
 
+var promoteBlobStoreScriptName = String("nexus3-go-promote-blobstore")
+var promoteBlobStoreScript = String(`
+import groovy.json.JsonSlurper
+import org.sonatype.nexus.blobstore.group.BlobStoreGroupService
+
+parsed_args = new JsonSlurper().parseText(args)
+if (blobStore.getBlobStoreManager().get(parsed_args.name) == null) {
+	return "not exists"
+}
+container.lookup(BlobStoreGroupService.class.name).promote(parsed_args.name)
+return "promoted"
+`)
+
 var listBlobStoreScriptName = String("nexus3-go-list-blobstores")
 var listBlobStoreScript = String(`
 import groovy.json.JsonOutput
@@ -78,7 +139,9 @@ def json = JsonOutput.toJson(res)
 return json
 `)
 
-// BlobStore represents a blobstore instance
+// BlobStore represents a blobstore instance. When the server supports the blob store
+// REST API the usage fields and soft quota are populated instead of the internal
+// metadata, and GetBlobStore also returns the Path or S3Config of the store.
 type BlobStore struct {
 	Groupable        *bool            `json:"groupable"`
 	ContentDir       *BlobDir         `json:"contentDir"`
@@ -91,6 +154,40 @@ type BlobStore struct {
 	Config           *BlobStoreConfig `json:"blobStoreConfiguration"`
 	Started          *bool            `json:"started"`
 	StateGuard       *StateGuard      `json:"stateGuard"`
+
+	Path                  *string                `json:"path,omitempty"`
+	S3Config              *S3BlobStoreConfig     `json:"s3Config,omitempty"`
+	GroupConfig           *GroupBlobStoreConfig  `json:"groupConfig,omitempty"`
+	AzureConfig           *AzureBlobStoreConfig  `json:"azureConfig,omitempty"`
+	GoogleConfig          *GoogleBlobStoreConfig `json:"googleConfig,omitempty"`
+	SoftQuota             *BlobStoreSoftQuota    `json:"softQuota,omitempty"`
+	BlobCount             *int64                 `json:"blobCount,omitempty"`
+	TotalSizeInBytes      *int64                 `json:"totalSizeInBytes,omitempty"`
+	AvailableSpaceInBytes *int64                 `json:"availableSpaceInBytes,omitempty"`
+}
+
+// createInput returns the parameters that would create a copy of the blob store
+func (b *BlobStore) createInput() *CreateBlobStoreInput {
+	input := &CreateBlobStoreInput{
+		Path:         b.Path,
+		S3Config:     b.S3Config,
+		GroupConfig:  b.GroupConfig,
+		AzureConfig:  b.AzureConfig,
+		GoogleConfig: b.GoogleConfig,
+		SoftQuota:    b.SoftQuota,
+	}
+	if b.Config != nil {
+		input.Name = b.Config.Name
+		input.Type = b.Config.Type
+	}
+	return input
+}
+
+// BlobStoreSoftQuota raises a quota violation when a blob store has less than
+// Limit bytes remaining, or uses more than Limit bytes, depending on the Type.
+type BlobStoreSoftQuota struct {
+	Type  *string `json:"type"`
+	Limit *int64  `json:"limit"`
 }
 
 // BlobDir is part of the metadata of a blobstore
@@ -135,19 +232,63 @@ type BlobStoreQuotaStatus struct {
 	BlobStoreName *string `json:"blobStoreName"`
 }
 
-// CreateBlobStoreInput provides parameters to a CreateBlobStore call.
-// Type must be one of BlobStoreTypeFile or BlobStoreTypeS3. For a File type
-// provide a path, for an s3 type provide an S3BlobStoreConfig.
+// CreateBlobStoreInput provides parameters to a CreateBlobStore or UpdateBlobStore call.
+// Type must be one of BlobStoreTypeFile, BlobStoreTypeS3, BlobStoreTypeGroup,
+// BlobStoreTypeAzure or BlobStoreTypeGoogle, and the configuration for the type
+// must be provided: a path for a File type, or the matching config for the others.
+// Every type other than File and S3, as well as SoftQuota, requires the blob store REST API.
 type CreateBlobStoreInput struct {
-	Name     *string            `json:"name"`
-	Type     *string            `json:"type"`
-	Path     *string            `json:"path"`
-	S3Config *S3BlobStoreConfig `json:"config"`
+	Name         *string                `json:"name"`
+	Type         *string                `json:"type"`
+	Path         *string                `json:"path"`
+	S3Config     *S3BlobStoreConfig     `json:"config"`
+	GroupConfig  *GroupBlobStoreConfig  `json:"groupConfig,omitempty"`
+	AzureConfig  *AzureBlobStoreConfig  `json:"azureConfig,omitempty"`
+	GoogleConfig *GoogleBlobStoreConfig `json:"googleConfig,omitempty"`
+	SoftQuota    *BlobStoreSoftQuota    `json:"softQuota,omitempty"`
+}
+
+// AzureBlobStoreConfig represents an Azure Blob Storage container used by a blob store.
+// AccountKey is only used with AzureAuthenticationMethodAccountKey and is never returned.
+type AzureBlobStoreConfig struct {
+	AccountName          *string `json:"accountName"`
+	ContainerName        *string `json:"containerName"`
+	AuthenticationMethod *string `json:"authenticationMethod"`
+	AccountKey           *string `json:"accountKey,omitempty"`
+}
+
+// GoogleBlobStoreConfig represents a Google Cloud Storage bucket used by a blob store.
+// CredentialFile is the path to a service account key file, which is read when the
+// blob store is created or updated. Without it Nexus uses the application default
+// credentials of the host it runs on.
+type GoogleBlobStoreConfig struct {
+	Bucket         *string `json:"bucket"`
+	Prefix         *string `json:"prefix,omitempty"`
+	Region         *string `json:"region,omitempty"`
+	CredentialFile *string `json:"credentialFile,omitempty"`
+
+	// accountKey is set when Nexus reports that the bucket uses a service account key
+	accountKey bool
+}
+
+// GroupBlobStoreConfig represents the members of a group blob store. The FillPolicy
+// decides which member new blobs are written to, either spreading them across all
+// writable members or filling the first writable member. Members can be added to a
+// group with UpdateBlobStore, but a member can only be removed once it is empty or
+// read-only.
+type GroupBlobStoreConfig struct {
+	Members    []string `json:"members"`
+	FillPolicy *string  `json:"fillPolicy,omitempty"`
 }
 
 // S3BlobStoreConfig represents an S3 bucket configuration for a blob store.
 // Expiration is required and is the time (in days deleted blobs last in the bucket.
 // To disable supply -1
+//
+// EncryptionKey is the KMS key ID used with S3EncryptionTypeKMS, and the AWS managed
+// key is used when it is not set. ForcePathStyle is needed for S3 compatible servers
+// such as MinIO that do not support virtual-hosted buckets. FailoverBuckets are only
+// supported by the blob store REST API.
 type S3BlobStoreConfig struct {
 	Bucket          *string `json:"bucket"`
 	Prefix          *string `json:"prefix"`
@@ -159,6 +300,19 @@ type S3BlobStoreConfig struct {
 	Endpoint        *string `json:"endpoint"`
 	Expiration      *int    `json:"expiration"`
 	SignerType      *string `json:"signerType"`
+
+	EncryptionType        *string             `json:"encryptionType,omitempty"`
+	EncryptionKey         *string             `json:"encryptionKey,omitempty"`
+	ForcePathStyle        *bool               `json:"forcePathStyle,omitempty"`
+	MaxConnectionPoolSize *int                `json:"maxConnectionPoolSize,omitempty"`
+	FailoverBuckets       []*S3FailoverBucket `json:"failoverBuckets,omitempty"`
+}
+
+// S3FailoverBucket is a bucket in another region that an S3 blob store switches
+// to when its primary region is unavailable. The buckets must be replicated.
+type S3FailoverBucket struct {
+	Region     *string `json:"region"`
+	BucketName *string `json:"bucketName"`
 }
 
 // DeleteBlobStoreInput provides paraameters to a DeleteBlobStore call
@@ -167,8 +321,22 @@ type DeleteBlobStoreInput struct {
 	Force *bool   `json:"force"`
 }
 
-// ListBlobStores returns a list of the blobstores on the Nexus server
+// ListBlobStores returns a list of the blobstores on the Nexus server. The blob
+// store REST API is used when the server supports it, otherwise a groovy script
+// is installed to read the blob stores.
 func (n *Nexus) ListBlobStores() (blobstores []*BlobStore, err error) {
+	return n.ListBlobStoresWithContext(context.Background())
+}
+
+// ListBlobStoresWithContext is the same as ListBlobStores with the addition of a context
+func (n *Nexus) ListBlobStoresWithContext(ctx context.Context) (blobstores []*BlobStore, err error) {
+	rest, err := n.blobStoreRESTAvailable(ctx)
+	if err != nil {
+		return
+	}
+	if rest {
+		return n.listBlobStoresREST(ctx)
+	}
 	blobstores = make([]*BlobStore, 0)
 	script := &Script{
 		Name:    listBlobStoreScriptName,
@@ -176,7 +344,7 @@ func (n *Nexus) ListBlobStores() (blobstores []*BlobStore, err error) {
 		Content: listBlobStoreScript,
 		client:  n,
 	}
-	res, err := script.ensureAndExecute(nil)
+	res, err := script.ensureAndExecute(ctx, nil)
 	if err != nil {
 		return
 	}
@@ -184,16 +352,22 @@ func (n *Nexus) ListBlobStores() (blobstores []*BlobStore, err error) {
 	return
 }
 
-// GetBlobStoreQuotaStatus retrieves the blobstore quota status for the given id
+// GetBlobStoreQuotaStatus retrieves the blobstore quota status for the given id,
+// which is the name of the blob store. It requires the blob store REST API.
 func (n *Nexus) GetBlobStoreQuotaStatus(id string) (res *BlobStoreQuotaStatus, err error) {
+	return n.GetBlobStoreQuotaStatusWithContext(context.Background(), id)
+}
+
+// GetBlobStoreQuotaStatusWithContext is the same as GetBlobStoreQuotaStatus with the addition of a context
+func (n *Nexus) GetBlobStoreQuotaStatusWithContext(ctx context.Context, id string) (res *BlobStoreQuotaStatus, err error) {
 	res = &BlobStoreQuotaStatus{}
-	endpoint := fmt.Sprintf("/v1/blobstores/%s/quota-status", id)
-	req, err := n.NewRequest("GET", endpoint, nil, nil, "")
+	endpoint := fmt.Sprintf("service/rest/v1/blobstores/%s/quota-status", id)
+	req, err := n.NewRequestWithContext(ctx, "GET", endpoint, nil, nil, "")
 	if err != nil {
 		return
 	}
 	body, err := n.Do(req, map[int]string{
-		404: fmt.Sprintf("No status with id %s found", id),
+		404: fmt.Sprintf("Blobstore %s does not exist", id),
 	}, false)
 	if err != nil {
 		return
@@ -204,7 +378,19 @@ func (n *Nexus) GetBlobStoreQuotaStatus(id string) (res *BlobStoreQuotaStatus, e
 
 // GetBlobStore retrieves a blobstore by the given name
 func (n *Nexus) GetBlobStore(name string) (store *BlobStore, err error) {
-	blobstores, err := n.ListBlobStores()
+	return n.GetBlobStoreWithContext(context.Background(), name)
+}
+
+// GetBlobStoreWithContext is the same as GetBlobStore with the addition of a context
+func (n *Nexus) GetBlobStoreWithContext(ctx context.Context, name string) (store *BlobStore, err error) {
+	rest, err := n.blobStoreRESTAvailable(ctx)
+	if err != nil {
+		return
+	}
+	if rest {
+		return n.getBlobStoreREST(ctx, name)
+	}
+	blobstores, err := n.ListBlobStoresWithContext(ctx)
 	if err != nil {
 		return
 	}
@@ -214,44 +400,177 @@ func (n *Nexus) GetBlobStore(name string) (store *BlobStore, err error) {
 			return
 		}
 	}
-	err = fmt.Errorf("Blobstore %s does not exist", name)
+	err = notFoundErrorf("Blobstore %s does not exist", name)
 	return
 }
 
 // CreateBlobStore creates a new blob store with the given parameters
 func (n *Nexus) CreateBlobStore(input *CreateBlobStoreInput) (blobstore *BlobStore, err error) {
+	return n.CreateBlobStoreWithContext(context.Background(), input)
+}
+
+// CreateBlobStoreWithContext is the same as CreateBlobStore with the addition of a context
+func (n *Nexus) CreateBlobStoreWithContext(ctx context.Context, input *CreateBlobStoreInput) (blobstore *BlobStore, err error) {
+	if input.Name == nil {
+		err = errors.New("Name is required for CreateBlobStore")
+		return
+	}
+	rest, err := n.blobStoreRESTAvailable(ctx)
+	if err != nil {
+		return
+	}
+	if rest {
+		if err = n.writeBlobStoreREST(ctx, true, input); err != nil {
+			return
+		}
+		blobstore, err = n.GetBlobStoreWithContext(ctx, *input.Name)
+		return
+	}
+	// The script only knows how to create file and S3 blob stores
+	if input.SoftQuota != nil || (input.Type != nil && *input.Type != *BlobStoreTypeFile && *input.Type != *BlobStoreTypeS3) ||
+		(input.S3Config != nil && len(input.S3Config.FailoverBuckets) > 0) {
+		err = errBlobStoreRESTRequired
+		return
+	}
 	script := &Script{
 		Name:    createBlobStoreScriptName,
 		Type:    ScriptTypeGroovy,
 		Content: createBlobStoreScript,
 		client:  n,
 	}
-	res, err := script.ensureAndExecute(input)
+	res, err := script.ensureAndExecute(ctx, input)
 	if err != nil {
 		return
 	}
 	if *res.Result == "exists" {
-		err = fmt.Errorf("Blobstore %s already exists", *input.Name)
+		err = conflictErrorf("Blobstore %s already exists", *input.Name)
+		return
+	}
+	blobstore, err = n.GetBlobStoreWithContext(ctx, *input.Name)
+	return
+}
+
+// UpdateBlobStore changes the configuration of an existing blob store. It takes
+// the same parameters as CreateBlobStore and requires the blob store REST API.
+// The type of a blob store cannot be changed.
+func (n *Nexus) UpdateBlobStore(input *CreateBlobStoreInput) (blobstore *BlobStore, err error) {
+	return n.UpdateBlobStoreWithContext(context.Background(), input)
+}
+
+// UpdateBlobStoreWithContext is the same as UpdateBlobStore with the addition of a context
+func (n *Nexus) UpdateBlobStoreWithContext(ctx context.Context, input *CreateBlobStoreInput) (blobstore *BlobStore, err error) {
+	if input.Name == nil {
+		err = errors.New("Name is required for UpdateBlobStore")
 		return
 	}
-	blobstore, err = n.GetBlobStore(*input.Name)
+	rest, err := n.blobStoreRESTAvailable(ctx)
+	if err != nil {
+		return
+	}
+	if !rest {
+		err = errBlobStoreRESTRequired
+		return
+	}
+	if err = n.writeBlobStoreREST(ctx, false, input); err != nil {
+		return
+	}
+	blobstore, err = n.GetBlobStoreWithContext(ctx, *input.Name)
 	return
 }
 
-// DeleteBlobStore deletes a blobstore with the given parameters
+// DeleteBlobStore deletes a blobstore with the given parameters. Force is only
+// supported by the script implementation, the REST API refuses to delete blob
+// stores that are in use.
 func (n *Nexus) DeleteBlobStore(input *DeleteBlobStoreInput) (err error) {
+	return n.DeleteBlobStoreWithContext(context.Background(), input)
+}
+
+// DeleteBlobStoreWithContext is the same as DeleteBlobStore with the addition of a context
+func (n *Nexus) DeleteBlobStoreWithContext(ctx context.Context, input *DeleteBlobStoreInput) (err error) {
+	if input.Name == nil {
+		err = errors.New("Name is required for DeleteBlobStore")
+		return
+	}
+	rest, err := n.blobStoreRESTAvailable(ctx)
+	if err != nil {
+		return
+	}
+	if rest {
+		if input.Force != nil && *input.Force {
+			err = errors.New("Force deletion is not supported by the blob store REST API")
+			return
+		}
+		return n.deleteBlobStoreREST(ctx, *input.Name)
+	}
 	script := &Script{
 		Name:    deleteBlobStoreScriptName,
 		Type:    ScriptTypeGroovy,
 		Content: deleteBlobStoreScript,
 		client:  n,
 	}
-	res, err := script.ensureAndExecute(input)
+	res, err := script.ensureAndExecute(ctx, input)
+	if err != nil {
+		return
+	}
+	if *res.Result == "not exists" {
+		err = notFoundErrorf("Blobstore %s does not exist", *input.Name)
+	}
+	return
+}
+
+// PromoteBlobStoreToGroup converts an existing file or S3 blob store into a group
+// with the same name, so that repositories using it can be given more storage without
+// reconfiguring them. The original store is renamed with a "-promoted" suffix and
+// becomes the only member of the group. The blob store REST API is used when it is
+// available, otherwise a groovy script is installed to perform the promotion.
+//
+// Example
+//
+// Move a blob store to a new disk by promoting it and adding a member on the new disk
+//
+//     if err := client.PromoteBlobStoreToGroup("default"); err != nil {
+//         log.Fatal(err)
+//     }
+//     if _, err := client.CreateBlobStore(&nexus.CreateBlobStoreInput{
+//         Name: nexus.String("default-disk2"),
+//         Type: nexus.BlobStoreTypeFile,
+//         Path: nexus.String("/mnt/disk2/default"),
+//     }); err != nil {
+//         log.Fatal(err)
+//     }
+//     _, err := client.UpdateBlobStore(&nexus.CreateBlobStoreInput{
+//         Name: nexus.String("default"),
+//         Type: nexus.BlobStoreTypeGroup,
+//         GroupConfig: &nexus.GroupBlobStoreConfig{
+//             Members:    []string{"default-disk2", "default-promoted"},
+//             FillPolicy: nexus.BlobStoreFillPolicyWriteToFirst,
+//         },
+//     })
+func (n *Nexus) PromoteBlobStoreToGroup(name string) (err error) {
+	return n.PromoteBlobStoreToGroupWithContext(context.Background(), name)
+}
+
+// PromoteBlobStoreToGroupWithContext is the same as PromoteBlobStoreToGroup with the addition of a context
+func (n *Nexus) PromoteBlobStoreToGroupWithContext(ctx context.Context, name string) (err error) {
+	rest, err := n.blobStoreRESTAvailable(ctx)
+	if err != nil {
+		return
+	}
+	if rest {
+		return n.promoteBlobStoreREST(ctx, name, name+"-promoted")
+	}
+	script := &Script{
+		Name:    promoteBlobStoreScriptName,
+		Type:    ScriptTypeGroovy,
+		Content: promoteBlobStoreScript,
+		client:  n,
+	}
+	res, err := script.ensureAndExecute(ctx, map[string]string{"name": name})
 	if err != nil {
 		return
 	}
 	if *res.Result == "not exists" {
-		err = fmt.Errorf("Blobstore %s does not exist", *input.Name)
+		err = notFoundErrorf("Blobstore %s does not exist", name)
 	}
 	return
 }
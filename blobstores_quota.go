@@ -0,0 +1,163 @@
+package nexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// BlobStoreUsage is an entry in the report returned by GetBlobStoreUsage
+type BlobStoreUsage struct {
+	Name                  string
+	Type                  string
+	BlobCount             int64
+	TotalSizeInBytes      int64
+	AvailableSpaceInBytes int64
+	SoftQuota             *BlobStoreSoftQuota
+	QuotaStatus           *BlobStoreQuotaStatus
+}
+
+// FillLevel returns the fraction of the storage available to the blob store that
+// is in use, between 0 and 1. A blob store that reports no available space is full,
+// and only a blob store that reports neither a size nor available space has a fill
+// level of 0.
+func (u *BlobStoreUsage) FillLevel() float64 {
+	used := float64(u.TotalSizeInBytes)
+	available := float64(u.AvailableSpaceInBytes)
+	if available < 0 {
+		available = 0
+	}
+	if used <= 0 {
+		return 0
+	}
+	return used / (used + available)
+}
+
+// SetBlobStoreQuota sets the soft quota of an existing blob store. The rest of
+// the configuration returned by GetBlobStore is submitted unchanged, and since
+// Nexus never returns the credentials of a blob store, an error is returned for
+// S3 blob stores with an access key, Azure blob stores using an account key and
+// Google blob stores using a service account key. Use UpdateBlobStore with the
+// credentials and SoftQuota set to change the quota of those blob stores.
+// It requires the blob store REST API.
+//
+// Example
+//
+// Raise a quota violation when less than 10GB is left on the disk of a blob store
+//
+//     err := client.SetBlobStoreQuota("default", &nexus.BlobStoreSoftQuota{
+//         Type:  nexus.BlobStoreQuotaTypeSpaceRemaining,
+//         Limit: nexus.Int64(10 * 1024 * 1024 * 1024),
+//     })
+func (n *Nexus) SetBlobStoreQuota(name string, quota *BlobStoreSoftQuota) (err error) {
+	return n.SetBlobStoreQuotaWithContext(context.Background(), name, quota)
+}
+
+// SetBlobStoreQuotaWithContext is the same as SetBlobStoreQuota with the addition of a context
+func (n *Nexus) SetBlobStoreQuotaWithContext(ctx context.Context, name string, quota *BlobStoreSoftQuota) (err error) {
+	if quota == nil || quota.Type == nil || quota.Limit == nil {
+		err = errors.New("Type and Limit are required for a blob store quota")
+		return
+	}
+	return n.setBlobStoreQuota(ctx, name, quota)
+}
+
+// RemoveBlobStoreQuota removes the soft quota from an existing blob store. Like
+// SetBlobStoreQuota, it returns an error for S3, Azure and Google blob stores with
+// static credentials, use UpdateBlobStore without a SoftQuota for those instead.
+func (n *Nexus) RemoveBlobStoreQuota(name string) (err error) {
+	return n.RemoveBlobStoreQuotaWithContext(context.Background(), name)
+}
+
+// RemoveBlobStoreQuotaWithContext is the same as RemoveBlobStoreQuota with the addition of a context
+func (n *Nexus) RemoveBlobStoreQuotaWithContext(ctx context.Context, name string) (err error) {
+	return n.setBlobStoreQuota(ctx, name, nil)
+}
+
+func (n *Nexus) setBlobStoreQuota(ctx context.Context, name string, quota *BlobStoreSoftQuota) (err error) {
+	rest, err := n.blobStoreRESTAvailable(ctx)
+	if err != nil {
+		return
+	}
+	if !rest {
+		err = errBlobStoreRESTRequired
+		return
+	}
+	store, err := n.GetBlobStoreWithContext(ctx, name)
+	if err != nil {
+		return
+	}
+	if store.hasStaticCredentials() {
+		err = fmt.Errorf("Blobstore %s uses credentials that Nexus does not return, use UpdateBlobStore with the credentials to change its quota", name)
+		return
+	}
+	input := store.createInput()
+	input.SoftQuota = quota
+	_, err = n.UpdateBlobStoreWithContext(ctx, input)
+	return
+}
+
+// hasStaticCredentials reports whether the blob store was configured with a secret
+// that GetBlobStore leaves out, so resubmitting its configuration would drop it.
+func (b *BlobStore) hasStaticCredentials() bool {
+	switch {
+	case b.S3Config != nil:
+		return stringValue(b.S3Config.AccessKeyID) != ""
+	case b.AzureConfig != nil:
+		return stringValue(b.AzureConfig.AuthenticationMethod) == *AzureAuthenticationMethodAccountKey
+	case b.GoogleConfig != nil:
+		return b.GoogleConfig.accountKey
+	}
+	return false
+}
+
+// GetBlobStoreUsage returns the size, blob count and available space of every
+// blob store, sorted from the fullest to the emptiest. The quota status is included
+// for blob stores with a soft quota. It requires the blob store REST API.
+func (n *Nexus) GetBlobStoreUsage() (res []*BlobStoreUsage, err error) {
+	return n.GetBlobStoreUsageWithContext(context.Background())
+}
+
+// GetBlobStoreUsageWithContext is the same as GetBlobStoreUsage with the addition of a context
+func (n *Nexus) GetBlobStoreUsageWithContext(ctx context.Context) (res []*BlobStoreUsage, err error) {
+	rest, err := n.blobStoreRESTAvailable(ctx)
+	if err != nil {
+		return
+	}
+	if !rest {
+		err = errBlobStoreRESTRequired
+		return
+	}
+	stores, err := n.listBlobStoresREST(ctx)
+	if err != nil {
+		return
+	}
+	res = make([]*BlobStoreUsage, 0, len(stores))
+	for _, store := range stores {
+		usage := &BlobStoreUsage{
+			Name:      stringValue(store.Config.Name),
+			Type:      stringValue(store.Config.Type),
+			SoftQuota: store.SoftQuota,
+		}
+		if store.BlobCount != nil {
+			usage.BlobCount = *store.BlobCount
+		}
+		if store.TotalSizeInBytes != nil {
+			usage.TotalSizeInBytes = *store.TotalSizeInBytes
+		}
+		if store.AvailableSpaceInBytes != nil {
+			usage.AvailableSpaceInBytes = *store.AvailableSpaceInBytes
+		}
+		if store.SoftQuota != nil {
+			if usage.QuotaStatus, err = n.GetBlobStoreQuotaStatusWithContext(ctx, usage.Name); err != nil {
+				return
+			}
+		}
+		res = append(res, usage)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].FillLevel() > res[j].FillLevel()
+	})
+	return
+}
@@ -0,0 +1,108 @@
+package nexus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBlobStoreUsageFillLevel(t *testing.T) {
+	for _, tc := range []struct {
+		used, available int64
+		expected        float64
+	}{
+		{0, 0, 0},
+		{0, 100, 0},
+		{100, 0, 1},
+		{100, -1, 1},
+		{25, 75, 0.25},
+		{75, 25, 0.75},
+	} {
+		usage := &BlobStoreUsage{TotalSizeInBytes: tc.used, AvailableSpaceInBytes: tc.available}
+		if actual := usage.FillLevel(); actual != tc.expected {
+			t.Errorf("FillLevel with %d used and %d available: expected %v, got %v", tc.used, tc.available, tc.expected, actual)
+		}
+	}
+}
+
+func TestBlobStoreHasStaticCredentials(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		store    *BlobStore
+		expected bool
+	}{
+		{"file", &BlobStore{Path: String("default")}, false},
+		{"group", &BlobStore{GroupConfig: &GroupBlobStoreConfig{Members: []string{"a"}}}, false},
+		{"s3 with an access key", &BlobStore{S3Config: &S3BlobStoreConfig{AccessKeyID: String("AKIA")}}, true},
+		{"s3 with an empty access key", &BlobStore{S3Config: &S3BlobStoreConfig{AccessKeyID: String("")}}, false},
+		{"s3 with instance credentials", &BlobStore{S3Config: &S3BlobStoreConfig{Bucket: String("b")}}, false},
+		{"azure with an account key", &BlobStore{AzureConfig: &AzureBlobStoreConfig{AuthenticationMethod: AzureAuthenticationMethodAccountKey}}, true},
+		{"azure with a managed identity", &BlobStore{AzureConfig: &AzureBlobStoreConfig{AuthenticationMethod: AzureAuthenticationMethodManagedIdentity}}, false},
+		{"google with a service account key", &BlobStore{GoogleConfig: &GoogleBlobStoreConfig{Bucket: String("b"), accountKey: true}}, true},
+		{"google with default credentials", &BlobStore{GoogleConfig: &GoogleBlobStoreConfig{Bucket: String("b")}}, false},
+	} {
+		if actual := tc.store.hasStaticCredentials(); actual != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, actual)
+		}
+	}
+}
+
+func TestSetBlobStoreQuotaKeepsCredentials(t *testing.T) {
+	updated := make(map[string]bool)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/service/rest/v1/blobstores")
+		switch {
+		case r.Method == "PUT":
+			updated[path] = true
+			w.WriteHeader(http.StatusNoContent)
+		case path == "":
+			w.Write([]byte(`[
+				{"name":"s3","type":"S3"},
+				{"name":"s3-role","type":"S3"},
+				{"name":"azure","type":"Azure Cloud Storage"},
+				{"name":"google","type":"Google Cloud Storage"},
+				{"name":"google-default","type":"Google Cloud Storage"}
+			]`))
+		case path == "/s3/s3":
+			w.Write([]byte(`{"name":"s3","bucketConfiguration":{"bucket":{"name":"b","region":"us-east-1","expiration":3},"bucketSecurity":{"accessKeyId":"AKIA"}}}`))
+		case path == "/s3/s3-role":
+			w.Write([]byte(`{"name":"s3-role","bucketConfiguration":{"bucket":{"name":"b","region":"us-east-1","expiration":3}}}`))
+		case path == "/azure/azure":
+			w.Write([]byte(`{"name":"azure","bucketConfiguration":{"accountName":"a","containerName":"c","authentication":{"authenticationMethod":"ACCOUNTKEY"}}}`))
+		case path == "/google/google":
+			w.Write([]byte(`{"name":"google","bucketConfiguration":{"bucket":{"name":"b"},"bucketSecurity":{"authenticationMethod":"accountKey"}}}`))
+		case path == "/google/google-default":
+			w.Write([]byte(`{"name":"google-default","bucketConfiguration":{"bucket":{"name":"b"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewWithOptions(srv.URL, WithoutStatusCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	quota := &BlobStoreSoftQuota{Type: BlobStoreQuotaTypeSpaceRemaining, Limit: Int64(1024)}
+	for _, tc := range []struct {
+		name, path string
+		refused    bool
+	}{
+		{"s3", "/s3/s3", true},
+		{"s3-role", "/s3/s3-role", false},
+		{"azure", "/azure/azure", true},
+		{"google", "/google/google", true},
+		{"google-default", "/google/google-default", false},
+	} {
+		err := client.SetBlobStoreQuota(tc.name, quota)
+		if tc.refused && (err == nil || !strings.Contains(err.Error(), "credentials")) {
+			t.Errorf("%s: expected the quota change to be refused, got %v", tc.name, err)
+		}
+		if !tc.refused && err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+		}
+		if updated[tc.path] == tc.refused {
+			t.Errorf("%s: expected an update %v, got %v", tc.name, !tc.refused, updated[tc.path])
+		}
+	}
+}
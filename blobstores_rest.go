@@ -0,0 +1,537 @@
+package nexus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync/atomic"
+)
+
+// The values of Nexus.blobStoreAPI
+const (
+	blobStoreAPIUnknown int32 = iota
+	blobStoreAPIREST
+	blobStoreAPIScript
+)
+
+// errBlobStoreRESTRequired is returned for operations that have no script equivalent
+var errBlobStoreRESTRequired = errors.New("This operation requires the blob store REST API, which this version of Nexus or this user cannot access")
+
+// blobStoreRESTAvailable reports whether the blob store REST API can be used. Servers
+// without it respond with a 404 or 405, which is cached on the client along with
+// a successful response. A 403 is returned to users that can run scripts without
+// being allowed to read blob stores, so the script fallback is used without caching
+// the result, since the permissions of the user may change.
+func (n *Nexus) blobStoreRESTAvailable(ctx context.Context) (ok bool, err error) {
+	switch atomic.LoadInt32(&n.blobStoreAPI) {
+	case blobStoreAPIREST:
+		return true, nil
+	case blobStoreAPIScript:
+		return false, nil
+	}
+	req, err := n.NewRequestWithContext(ctx, "GET", "service/rest/v1/blobstores", nil, nil, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, nil, false)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case 404, 405:
+			atomic.StoreInt32(&n.blobStoreAPI, blobStoreAPIScript)
+			return false, nil
+		case 403:
+			return false, nil
+		}
+	}
+	if err != nil {
+		return
+	}
+	atomic.StoreInt32(&n.blobStoreAPI, blobStoreAPIREST)
+	return true, nil
+}
+
+// restBlobStore is a blob store as returned by the REST listing
+type restBlobStore struct {
+	Name                  *string             `json:"name"`
+	Type                  *string             `json:"type"`
+	Unavailable           *bool               `json:"unavailable"`
+	SoftQuota             *BlobStoreSoftQuota `json:"softQuota"`
+	BlobCount             *int64              `json:"blobCount"`
+	TotalSizeInBytes      *int64              `json:"totalSizeInBytes"`
+	AvailableSpaceInBytes *int64              `json:"availableSpaceInBytes"`
+}
+
+func (r *restBlobStore) blobStore() *BlobStore {
+	store := &BlobStore{
+		Config: &BlobStoreConfig{
+			Name: r.Name,
+			Type: r.Type,
+		},
+		SoftQuota:             r.SoftQuota,
+		BlobCount:             r.BlobCount,
+		TotalSizeInBytes:      r.TotalSizeInBytes,
+		AvailableSpaceInBytes: r.AvailableSpaceInBytes,
+	}
+	if r.Unavailable != nil {
+		store.StorageAvailable = Bool(!*r.Unavailable)
+	}
+	return store
+}
+
+// restFileBlobStore is the payload of the file blob store endpoints
+type restFileBlobStore struct {
+	Name      *string             `json:"name,omitempty"`
+	Path      *string             `json:"path"`
+	SoftQuota *BlobStoreSoftQuota `json:"softQuota,omitempty"`
+}
+
+// restGroupBlobStore is the payload of the group blob store endpoints
+type restGroupBlobStore struct {
+	Name       *string             `json:"name,omitempty"`
+	SoftQuota  *BlobStoreSoftQuota `json:"softQuota,omitempty"`
+	Members    []string            `json:"members"`
+	FillPolicy *string             `json:"fillPolicy,omitempty"`
+}
+
+// restAzureBlobStore is the payload of the azure blob store endpoints
+type restAzureBlobStore struct {
+	Name                *string                       `json:"name,omitempty"`
+	SoftQuota           *BlobStoreSoftQuota           `json:"softQuota,omitempty"`
+	BucketConfiguration *restAzureBucketConfiguration `json:"bucketConfiguration"`
+}
+
+type restAzureBucketConfiguration struct {
+	AccountName    *string                  `json:"accountName"`
+	ContainerName  *string                  `json:"containerName"`
+	Authentication *restAzureAuthentication `json:"authentication"`
+}
+
+type restAzureAuthentication struct {
+	AuthenticationMethod *string `json:"authenticationMethod"`
+	AccountKey           *string `json:"accountKey,omitempty"`
+}
+
+// restGoogleBlobStore is the payload of the google blob store endpoints
+type restGoogleBlobStore struct {
+	Name                *string                        `json:"name,omitempty"`
+	SoftQuota           *BlobStoreSoftQuota            `json:"softQuota,omitempty"`
+	BucketConfiguration *restGoogleBucketConfiguration `json:"bucketConfiguration"`
+}
+
+type restGoogleBucketConfiguration struct {
+	Bucket         *restGoogleBucket         `json:"bucket"`
+	BucketSecurity *restGoogleBucketSecurity `json:"bucketSecurity,omitempty"`
+}
+
+type restGoogleBucket struct {
+	Name   *string `json:"name"`
+	Prefix *string `json:"prefix,omitempty"`
+	Region *string `json:"region,omitempty"`
+}
+
+type restGoogleBucketSecurity struct {
+	AuthenticationMethod *string `json:"authenticationMethod"`
+	AccountKey           *string `json:"accountKey,omitempty"`
+}
+
+func newRESTGoogleBucketConfiguration(config *GoogleBlobStoreConfig) (bucket *restGoogleBucketConfiguration, err error) {
+	bucket = &restGoogleBucketConfiguration{
+		Bucket: &restGoogleBucket{
+			Name:   config.Bucket,
+			Prefix: config.Prefix,
+			Region: config.Region,
+		},
+	}
+	if config.CredentialFile == nil {
+		return
+	}
+	key, err := ioutil.ReadFile(*config.CredentialFile)
+	if err != nil {
+		return
+	}
+	bucket.BucketSecurity = &restGoogleBucketSecurity{
+		AuthenticationMethod: String("accountKey"),
+		AccountKey:           String(string(key)),
+	}
+	return
+}
+
+// restS3BlobStore is the payload of the s3 blob store endpoints, which nest
+// the settings that S3BlobStoreConfig keeps flat.
+type restS3BlobStore struct {
+	Name                *string                    `json:"name,omitempty"`
+	SoftQuota           *BlobStoreSoftQuota        `json:"softQuota,omitempty"`
+	BucketConfiguration *restS3BucketConfiguration `json:"bucketConfiguration"`
+}
+
+type restS3BucketConfiguration struct {
+	Bucket                   *restS3Bucket                   `json:"bucket"`
+	Encryption               *restS3Encryption               `json:"encryption,omitempty"`
+	BucketSecurity           *restS3BucketSecurity           `json:"bucketSecurity,omitempty"`
+	AdvancedBucketConnection *restS3AdvancedBucketConnection `json:"advancedBucketConnection,omitempty"`
+	FailoverBuckets          []*S3FailoverBucket             `json:"failoverBuckets,omitempty"`
+}
+
+type restS3Encryption struct {
+	EncryptionType *string `json:"encryptionType,omitempty"`
+	EncryptionKey  *string `json:"encryptionKey,omitempty"`
+}
+
+type restS3Bucket struct {
+	Region     *string `json:"region"`
+	Name       *string `json:"name"`
+	Prefix     *string `json:"prefix,omitempty"`
+	Expiration *int    `json:"expiration"`
+}
+
+type restS3BucketSecurity struct {
+	AccessKeyID     *string `json:"accessKeyId,omitempty"`
+	SecretAccessKey *string `json:"secretAccessKey,omitempty"`
+	Role            *string `json:"role,omitempty"`
+	SessionToken    *string `json:"sessionToken,omitempty"`
+}
+
+type restS3AdvancedBucketConnection struct {
+	Endpoint              *string `json:"endpoint,omitempty"`
+	SignerType            *string `json:"signerType,omitempty"`
+	ForcePathStyle        *bool   `json:"forcePathStyle,omitempty"`
+	MaxConnectionPoolSize *int    `json:"maxConnectionPoolSize,omitempty"`
+}
+
+func newRESTS3BucketConfiguration(config *S3BlobStoreConfig) *restS3BucketConfiguration {
+	bucket := &restS3BucketConfiguration{
+		Bucket: &restS3Bucket{
+			Region:     config.Region,
+			Name:       config.Bucket,
+			Prefix:     config.Prefix,
+			Expiration: config.Expiration,
+		},
+		FailoverBuckets: config.FailoverBuckets,
+	}
+	if bucket.Bucket.Region == nil {
+		bucket.Bucket.Region = String("DEFAULT")
+	}
+	if config.AccessKeyID != nil || config.AssumeRole != nil {
+		bucket.BucketSecurity = &restS3BucketSecurity{
+			AccessKeyID:     config.AccessKeyID,
+			SecretAccessKey: config.SecretAccessKey,
+			Role:            config.AssumeRole,
+			SessionToken:    config.SessionToken,
+		}
+	}
+	if config.EncryptionType != nil {
+		bucket.Encryption = &restS3Encryption{
+			EncryptionType: config.EncryptionType,
+			EncryptionKey:  config.EncryptionKey,
+		}
+	}
+	if config.Endpoint != nil || config.SignerType != nil || config.ForcePathStyle != nil || config.MaxConnectionPoolSize != nil {
+		bucket.AdvancedBucketConnection = &restS3AdvancedBucketConnection{
+			Endpoint:              config.Endpoint,
+			SignerType:            config.SignerType,
+			ForcePathStyle:        config.ForcePathStyle,
+			MaxConnectionPoolSize: config.MaxConnectionPoolSize,
+		}
+	}
+	return bucket
+}
+
+func (b *restS3BucketConfiguration) s3Config() *S3BlobStoreConfig {
+	config := &S3BlobStoreConfig{FailoverBuckets: b.FailoverBuckets}
+	if b.Bucket != nil {
+		config.Region = b.Bucket.Region
+		config.Bucket = b.Bucket.Name
+		config.Prefix = b.Bucket.Prefix
+		config.Expiration = b.Bucket.Expiration
+	}
+	if b.BucketSecurity != nil {
+		config.AccessKeyID = b.BucketSecurity.AccessKeyID
+		config.SecretAccessKey = b.BucketSecurity.SecretAccessKey
+		config.AssumeRole = b.BucketSecurity.Role
+		config.SessionToken = b.BucketSecurity.SessionToken
+	}
+	if b.Encryption != nil {
+		config.EncryptionType = b.Encryption.EncryptionType
+		config.EncryptionKey = b.Encryption.EncryptionKey
+	}
+	if b.AdvancedBucketConnection != nil {
+		config.Endpoint = b.AdvancedBucketConnection.Endpoint
+		config.SignerType = b.AdvancedBucketConnection.SignerType
+		config.ForcePathStyle = b.AdvancedBucketConnection.ForcePathStyle
+		config.MaxConnectionPoolSize = b.AdvancedBucketConnection.MaxConnectionPoolSize
+	}
+	return config
+}
+
+// blobStoreTypePaths maps the lower-cased blob store types to their endpoint segment
+var blobStoreTypePaths = map[string]string{
+	"file":                 "file",
+	"s3":                   "s3",
+	"group":                "group",
+	"azure":                "azure",
+	"azure cloud storage":  "azure",
+	"google":               "google",
+	"google cloud storage": "google",
+}
+
+// blobStoreTypePath returns the endpoint segment for a blob store type
+func blobStoreTypePath(blobStoreType *string) (path string, err error) {
+	if blobStoreType == nil {
+		err = errors.New("Type is required for a blob store")
+		return
+	}
+	path, ok := blobStoreTypePaths[strings.ToLower(*blobStoreType)]
+	if !ok {
+		err = fmt.Errorf("Blob store type %s is not supported", *blobStoreType)
+	}
+	return
+}
+
+// restBlobStorePayload builds the request body for creating or updating a blob store
+func restBlobStorePayload(input *CreateBlobStoreInput, typePath string) (payload interface{}, err error) {
+	switch typePath {
+	case "file":
+		path := input.Path
+		if path == nil {
+			path = input.Name
+		}
+		payload = &restFileBlobStore{Name: input.Name, Path: path, SoftQuota: input.SoftQuota}
+	case "s3":
+		if input.S3Config == nil {
+			err = errors.New("S3Config is required for an S3 blob store")
+			return
+		}
+		payload = &restS3BlobStore{
+			Name:                input.Name,
+			SoftQuota:           input.SoftQuota,
+			BucketConfiguration: newRESTS3BucketConfiguration(input.S3Config),
+		}
+	case "group":
+		if input.GroupConfig == nil {
+			err = errors.New("GroupConfig is required for a group blob store")
+			return
+		}
+		payload = &restGroupBlobStore{
+			Name:       input.Name,
+			SoftQuota:  input.SoftQuota,
+			Members:    input.GroupConfig.Members,
+			FillPolicy: input.GroupConfig.FillPolicy,
+		}
+	case "azure":
+		config := input.AzureConfig
+		if config == nil {
+			err = errors.New("AzureConfig is required for an Azure blob store")
+			return
+		}
+		payload = &restAzureBlobStore{
+			Name:      input.Name,
+			SoftQuota: input.SoftQuota,
+			BucketConfiguration: &restAzureBucketConfiguration{
+				AccountName:   config.AccountName,
+				ContainerName: config.ContainerName,
+				Authentication: &restAzureAuthentication{
+					AuthenticationMethod: config.AuthenticationMethod,
+					AccountKey:           config.AccountKey,
+				},
+			},
+		}
+	case "google":
+		if input.GoogleConfig == nil {
+			err = errors.New("GoogleConfig is required for a Google blob store")
+			return
+		}
+		var bucket *restGoogleBucketConfiguration
+		if bucket, err = newRESTGoogleBucketConfiguration(input.GoogleConfig); err != nil {
+			return
+		}
+		payload = &restGoogleBlobStore{
+			Name:                input.Name,
+			SoftQuota:           input.SoftQuota,
+			BucketConfiguration: bucket,
+		}
+	}
+	return
+}
+
+func (n *Nexus) listBlobStoresREST(ctx context.Context) (blobstores []*BlobStore, err error) {
+	blobstores = make([]*BlobStore, 0)
+	req, err := n.NewRequestWithContext(ctx, "GET", "service/rest/v1/blobstores", nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: "Insufficient permissions to list blob stores",
+	}, false)
+	if err != nil {
+		return
+	}
+	var res []*restBlobStore
+	if err = json.Unmarshal(body, &res); err != nil {
+		return
+	}
+	for _, x := range res {
+		blobstores = append(blobstores, x.blobStore())
+	}
+	return
+}
+
+// getBlobStoreREST finds the blob store in the listing and adds the
+// configuration returned by the endpoint for its type.
+func (n *Nexus) getBlobStoreREST(ctx context.Context, name string) (store *BlobStore, err error) {
+	blobstores, err := n.listBlobStoresREST(ctx)
+	if err != nil {
+		return
+	}
+	for _, x := range blobstores {
+		if *x.Config.Name == name {
+			store = x
+		}
+	}
+	if store == nil {
+		err = notFoundErrorf("Blobstore %s does not exist", name)
+		return
+	}
+	typePath, err := blobStoreTypePath(store.Config.Type)
+	if err != nil {
+		// Types without a typed endpoint are returned as listed
+		err = nil
+		return
+	}
+	endpoint := fmt.Sprintf("service/rest/v1/blobstores/%s/%s", typePath, name)
+	req, err := n.NewRequestWithContext(ctx, "GET", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to get blob store %s", name),
+		404: fmt.Sprintf("Blobstore %s does not exist", name),
+	}, false)
+	if err != nil {
+		return
+	}
+	switch typePath {
+	case "file":
+		res := &restFileBlobStore{}
+		if err = json.Unmarshal(body, res); err != nil {
+			return
+		}
+		store.Path = res.Path
+		store.SoftQuota = res.SoftQuota
+	case "s3":
+		res := &restS3BlobStore{}
+		if err = json.Unmarshal(body, res); err != nil {
+			return
+		}
+		if res.BucketConfiguration != nil {
+			store.S3Config = res.BucketConfiguration.s3Config()
+		}
+		store.SoftQuota = res.SoftQuota
+	case "group":
+		res := &restGroupBlobStore{}
+		if err = json.Unmarshal(body, res); err != nil {
+			return
+		}
+		store.GroupConfig = &GroupBlobStoreConfig{
+			Members:    res.Members,
+			FillPolicy: res.FillPolicy,
+		}
+		store.SoftQuota = res.SoftQuota
+	case "azure":
+		res := &restAzureBlobStore{}
+		if err = json.Unmarshal(body, res); err != nil {
+			return
+		}
+		if bucket := res.BucketConfiguration; bucket != nil {
+			store.AzureConfig = &AzureBlobStoreConfig{
+				AccountName:   bucket.AccountName,
+				ContainerName: bucket.ContainerName,
+			}
+			if bucket.Authentication != nil {
+				store.AzureConfig.AuthenticationMethod = bucket.Authentication.AuthenticationMethod
+			}
+		}
+		store.SoftQuota = res.SoftQuota
+	case "google":
+		res := &restGoogleBlobStore{}
+		if err = json.Unmarshal(body, res); err != nil {
+			return
+		}
+		if bucket := res.BucketConfiguration; bucket != nil && bucket.Bucket != nil {
+			store.GoogleConfig = &GoogleBlobStoreConfig{
+				Bucket: bucket.Bucket.Name,
+				Prefix: bucket.Bucket.Prefix,
+				Region: bucket.Bucket.Region,
+
+				accountKey: bucket.BucketSecurity != nil,
+			}
+		}
+		store.SoftQuota = res.SoftQuota
+	}
+	return
+}
+
+func (n *Nexus) writeBlobStoreREST(ctx context.Context, create bool, input *CreateBlobStoreInput) (err error) {
+	typePath, err := blobStoreTypePath(input.Type)
+	if err != nil {
+		return
+	}
+	payload, err := restBlobStorePayload(input, typePath)
+	if err != nil {
+		return
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	method := "PUT"
+	endpoint := fmt.Sprintf("service/rest/v1/blobstores/%s/%s", typePath, *input.Name)
+	statusMap := map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to update blob store %s", *input.Name),
+		404: fmt.Sprintf("Blobstore %s does not exist", *input.Name),
+	}
+	if create {
+		method = "POST"
+		endpoint = fmt.Sprintf("service/rest/v1/blobstores/%s", typePath)
+		statusMap = map[int]string{
+			403: fmt.Sprintf("Insufficient permissions to create blob store %s", *input.Name),
+		}
+	}
+	req, err := n.NewRequestWithContext(ctx, method, endpoint, nil, body, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, statusMap, false)
+	return
+}
+
+func (n *Nexus) deleteBlobStoreREST(ctx context.Context, name string) (err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/blobstores/%s", name)
+	req, err := n.NewRequestWithContext(ctx, "DELETE", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		400: fmt.Sprintf("Blobstore %s is in use", name),
+		403: fmt.Sprintf("Insufficient permissions to delete blob store %s", name),
+		404: fmt.Sprintf("Blobstore %s does not exist", name),
+	}, false)
+	return
+}
+
+func (n *Nexus) promoteBlobStoreREST(ctx context.Context, name string, newNameForOriginal string) (err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/blobstores/group/convert/%s/%s", name, newNameForOriginal)
+	req, err := n.NewRequestWithContext(ctx, "POST", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		400: fmt.Sprintf("Blobstore %s cannot be promoted to a group", name),
+		403: fmt.Sprintf("Insufficient permissions to promote blob store %s", name),
+		404: fmt.Sprintf("Blobstore %s does not exist", name),
+	}, false)
+	return
+}
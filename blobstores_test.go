@@ -0,0 +1,104 @@
+package nexus
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBlobStoreRESTAvailable(t *testing.T) {
+	for _, tc := range []struct {
+		status    int
+		available bool
+		fails     bool
+		probes    int
+	}{
+		{http.StatusOK, true, false, 1},
+		{http.StatusNotFound, false, false, 1},
+		{http.StatusMethodNotAllowed, false, false, 1},
+		{http.StatusForbidden, false, false, 2},
+		{http.StatusInternalServerError, false, true, 2},
+	} {
+		probes := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			probes++
+			w.WriteHeader(tc.status)
+			w.Write([]byte(`[]`))
+		}))
+		client, err := NewWithOptions(srv.URL, WithoutStatusCheck())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 2; i++ {
+			available, err := client.blobStoreRESTAvailable(context.Background())
+			if available != tc.available || (err != nil) != tc.fails {
+				t.Errorf("Status %d: expected %v and error %v, got %v and %v", tc.status, tc.available, tc.fails, available, err)
+			}
+		}
+		if probes != tc.probes {
+			t.Errorf("Status %d: expected %d requests, got %d", tc.status, tc.probes, probes)
+		}
+		srv.Close()
+	}
+}
+
+// newBlobStoreServer serves a single file blob store named default, either from the
+// blob store REST API or, when rest is false, from the blob store listing script.
+func newBlobStoreServer(t *testing.T, rest bool) *httptest.Server {
+	scriptPath := "/service/rest/v1/script/" + *listBlobStoreScriptName
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/service/rest/v1/blobstores" && !rest:
+			w.WriteHeader(http.StatusForbidden)
+		case r.URL.Path == "/service/rest/v1/blobstores":
+			w.Write([]byte(`[{"name":"default","type":"File","unavailable":false,"blobCount":3,"totalSizeInBytes":1024,"availableSpaceInBytes":4096}]`))
+		case r.URL.Path == "/service/rest/v1/blobstores/file/default" && rest:
+			w.Write([]byte(`{"name":"default","path":"/nexus-data/blobs/default"}`))
+		case r.URL.Path == scriptPath && !rest:
+			json.NewEncoder(w).Encode(&Script{Name: listBlobStoreScriptName, Type: ScriptTypeGroovy, Content: listBlobStoreScript})
+		case r.URL.Path == scriptPath+"/run" && !rest:
+			result := `[{"storageAvailable":true,"writable":true,"blobStoreConfiguration":{"name":"default","type":"File"}}]`
+			json.NewEncoder(w).Encode(&ExecuteScriptResponse{Name: listBlobStoreScriptName, Result: String(result)})
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestBlobStoreMappingMatchesAcrossAPIs(t *testing.T) {
+	for _, rest := range []bool{true, false} {
+		srv := newBlobStoreServer(t, rest)
+		client, err := NewWithOptions(srv.URL, WithoutStatusCheck())
+		if err != nil {
+			t.Fatal(err)
+		}
+		stores, err := client.ListBlobStores()
+		if err != nil {
+			t.Fatalf("REST %v: %v", rest, err)
+		}
+		store, err := client.GetBlobStore("default")
+		if err != nil {
+			t.Fatalf("REST %v: %v", rest, err)
+		}
+		for _, s := range []*BlobStore{stores[0], store} {
+			if len(stores) != 1 || s.Config == nil || stringValue(s.Config.Name) != "default" || !strings.EqualFold(stringValue(s.Config.Type), *BlobStoreTypeFile) {
+				t.Errorf("REST %v: unexpected blob store %+v", rest, s)
+			}
+			if s.StorageAvailable == nil || !*s.StorageAvailable {
+				t.Errorf("REST %v: expected the storage to be available", rest)
+			}
+		}
+		input := store.createInput()
+		if stringValue(input.Name) != "default" || !strings.EqualFold(stringValue(input.Type), *BlobStoreTypeFile) {
+			t.Errorf("REST %v: unexpected input %+v", rest, input)
+		}
+		if rest && stringValue(store.Path) != "/nexus-data/blobs/default" {
+			t.Errorf("Expected the path of the blob store, got %v", stringValue(store.Path))
+		}
+		srv.Close()
+	}
+}
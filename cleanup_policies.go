@@ -0,0 +1,148 @@
+package nexus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// CleanupPolicyReleaseTypeReleases and CleanupPolicyReleaseTypePrereleases are used
+// when specifying CleanupPolicy.CriteriaReleaseType
+var (
+	CleanupPolicyReleaseTypeReleases    = String("RELEASES")
+	CleanupPolicyReleaseTypePrereleases = String("PRERELEASES")
+)
+
+// CleanupPolicyAllFormats is used when specifying a CleanupPolicy.Format that
+// applies to repositories of any format
+var CleanupPolicyAllFormats = String("ALL_FORMATS")
+
+// CleanupPolicy represents a cleanup policy that can be applied to repositories.
+// Components matching all of the criteria that are set are removed when the
+// cleanup task runs. The last updated and last downloaded criteria are in days.
+type CleanupPolicy struct {
+	Name                    *string `json:"name"`
+	Notes                   *string `json:"notes,omitempty"`
+	Format                  *string `json:"format,omitempty"`
+	CriteriaLastBlobUpdated *int    `json:"criteriaLastBlobUpdated,omitempty"`
+	CriteriaLastDownloaded  *int    `json:"criteriaLastDownloaded,omitempty"`
+	CriteriaReleaseType     *string `json:"criteriaReleaseType,omitempty"`
+	CriteriaAssetRegex      *string `json:"criteriaAssetRegex,omitempty"`
+}
+
+// ListCleanupPolicies returns the cleanup policies configured in Nexus
+func (n *Nexus) ListCleanupPolicies() (res []*CleanupPolicy, err error) {
+	return n.ListCleanupPoliciesWithContext(context.Background())
+}
+
+// ListCleanupPoliciesWithContext is the same as ListCleanupPolicies with the addition of a context
+func (n *Nexus) ListCleanupPoliciesWithContext(ctx context.Context) (res []*CleanupPolicy, err error) {
+	res = make([]*CleanupPolicy, 0)
+	req, err := n.NewRequestWithContext(ctx, "GET", "service/rest/v1/cleanup-policies", nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: "Insufficient permissions to list cleanup policies",
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// GetCleanupPolicy retrieves a cleanup policy by name
+func (n *Nexus) GetCleanupPolicy(name string) (res *CleanupPolicy, err error) {
+	return n.GetCleanupPolicyWithContext(context.Background(), name)
+}
+
+// GetCleanupPolicyWithContext is the same as GetCleanupPolicy with the addition of a context
+func (n *Nexus) GetCleanupPolicyWithContext(ctx context.Context, name string) (res *CleanupPolicy, err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/cleanup-policies/%s", name)
+	req, err := n.NewRequestWithContext(ctx, "GET", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to get cleanup policy %s", name),
+		404: fmt.Sprintf("Cleanup policy %s does not exist", name),
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// CreateCleanupPolicy creates a new cleanup policy
+func (n *Nexus) CreateCleanupPolicy(policy *CleanupPolicy) (err error) {
+	return n.CreateCleanupPolicyWithContext(context.Background(), policy)
+}
+
+// CreateCleanupPolicyWithContext is the same as CreateCleanupPolicy with the addition of a context
+func (n *Nexus) CreateCleanupPolicyWithContext(ctx context.Context, policy *CleanupPolicy) (err error) {
+	if policy.Name == nil {
+		err = errors.New("Name is required for CreateCleanupPolicy")
+		return
+	}
+	payload, err := json.Marshal(policy)
+	if err != nil {
+		return
+	}
+	req, err := n.NewRequestWithContext(ctx, "POST", "service/rest/v1/cleanup-policies", nil, payload, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to create cleanup policy %s", *policy.Name),
+	}, false)
+	return
+}
+
+// UpdateCleanupPolicy replaces the criteria of an existing cleanup policy
+func (n *Nexus) UpdateCleanupPolicy(policy *CleanupPolicy) (err error) {
+	return n.UpdateCleanupPolicyWithContext(context.Background(), policy)
+}
+
+// UpdateCleanupPolicyWithContext is the same as UpdateCleanupPolicy with the addition of a context
+func (n *Nexus) UpdateCleanupPolicyWithContext(ctx context.Context, policy *CleanupPolicy) (err error) {
+	if policy.Name == nil {
+		err = errors.New("Name is required for UpdateCleanupPolicy")
+		return
+	}
+	payload, err := json.Marshal(policy)
+	if err != nil {
+		return
+	}
+	endpoint := fmt.Sprintf("service/rest/v1/cleanup-policies/%s", *policy.Name)
+	req, err := n.NewRequestWithContext(ctx, "PUT", endpoint, nil, payload, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to update cleanup policy %s", *policy.Name),
+		404: fmt.Sprintf("Cleanup policy %s does not exist", *policy.Name),
+	}, false)
+	return
+}
+
+// DeleteCleanupPolicy removes the cleanup policy with the given name
+func (n *Nexus) DeleteCleanupPolicy(name string) (err error) {
+	return n.DeleteCleanupPolicyWithContext(context.Background(), name)
+}
+
+// DeleteCleanupPolicyWithContext is the same as DeleteCleanupPolicy with the addition of a context
+func (n *Nexus) DeleteCleanupPolicyWithContext(ctx context.Context, name string) (err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/cleanup-policies/%s", name)
+	req, err := n.NewRequestWithContext(ctx, "DELETE", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to delete cleanup policy %s", name),
+		404: fmt.Sprintf("Cleanup policy %s does not exist", name),
+	}, false)
+	return
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	nexus "github.com/tinyzimmer/nexus3-go"
+	"sigs.k8s.io/yaml"
+)
+
+func applyState() {
+	body, err := ioutil.ReadFile(*applyFile)
+	checkErr(err)
+	desired := &nexus.DesiredState{}
+	checkErr(yaml.Unmarshal(body, desired))
+	client, err := newClient()
+	checkErr(err)
+	plan, err := client.Plan(&nexus.ReconcileInput{
+		Desired: desired,
+		Prune:   applyPrune,
+	})
+	checkErr(err)
+	fmt.Print(plan)
+	if *applyDryRun || plan.Empty() {
+		return
+	}
+	checkErr(client.ApplyPlan(plan))
+	fmt.Println("Apply complete")
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+func blobStoreUsage() {
+	client, err := newClient()
+	checkErr(err)
+	usage, err := client.GetBlobStoreUsage()
+	checkErr(err)
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tTYPE\tBLOBS\tSIZE\tAVAILABLE\tUSED\tQUOTA")
+	for _, x := range usage {
+		quota := "-"
+		if x.SoftQuota != nil && x.SoftQuota.Type != nil && x.SoftQuota.Limit != nil {
+			quota = fmt.Sprintf("%s %s", *x.SoftQuota.Type, formatBytes(*x.SoftQuota.Limit))
+			if x.QuotaStatus != nil && x.QuotaStatus.IsViolation != nil && *x.QuotaStatus.IsViolation {
+				quota += " (violated)"
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%.1f%%\t%s\n",
+			x.Name, x.Type, x.BlobCount, formatBytes(x.TotalSizeInBytes),
+			formatBytes(x.AvailableSpaceInBytes), x.FillLevel()*100, quota)
+	}
+	checkErr(w.Flush())
+}
+
+// formatBytes returns a size in bytes using binary units
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%dB", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
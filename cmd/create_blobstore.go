@@ -8,30 +8,70 @@ import (
 )
 
 func createBlobStore() {
-	client, err := nexus.New(*host, *username, *password)
-	var btype *string
-	if *createBlobStoreType == "file" {
-		btype = nexus.BlobStoreTypeFile
-	} else {
-		btype = nexus.BlobStoreTypeS3
-	}
+	client, err := newClient()
 	checkErr(err)
-	store, err := client.CreateBlobStore(&nexus.CreateBlobStoreInput{
+	input := &nexus.CreateBlobStoreInput{
 		Name: createBlobStoreName,
-		Type: btype,
-		Path: createBlobStorePath,
-		S3Config: &nexus.S3BlobStoreConfig{
+	}
+	switch *createBlobStoreType {
+	case "file":
+		input.Type = nexus.BlobStoreTypeFile
+		input.Path = createBlobStorePath
+	case "s3":
+		input.Type = nexus.BlobStoreTypeS3
+		input.S3Config = &nexus.S3BlobStoreConfig{
 			Bucket:          createBlobStoreBucket,
-			Prefix:          createBlobStorePrefix,
-			AccessKeyID:     createBlobStoreAccessKeyID,
-			SecretAccessKey: createBlobStoreSecretAccessKey,
-			AssumeRole:      createBlobStoreAssumeRole,
-			Region:          createBlobStoreRegion,
+			Prefix:          optional(createBlobStorePrefix),
+			AccessKeyID:     optional(createBlobStoreAccessKeyID),
+			SecretAccessKey: optional(createBlobStoreSecretAccessKey),
+			SessionToken:    optional(createBlobStoreSessionToken),
+			AssumeRole:      optional(createBlobStoreAssumeRole),
+			Region:          optional(createBlobStoreRegion),
+			Endpoint:        optional(createBlobStoreEndpoint),
 			Expiration:      createBlobStoreExpiration,
-		},
-	})
+			EncryptionType:  optional(createBlobStoreEncryption),
+			EncryptionKey:   optional(createBlobStoreEncryptionKey),
+		}
+		if *createBlobStoreForcePathStyle {
+			input.S3Config.ForcePathStyle = createBlobStoreForcePathStyle
+		}
+		if *createBlobStoreMaxConnections > 0 {
+			input.S3Config.MaxConnectionPoolSize = createBlobStoreMaxConnections
+		}
+	case "group":
+		input.Type = nexus.BlobStoreTypeGroup
+		input.GroupConfig = &nexus.GroupBlobStoreConfig{
+			Members:    *createBlobStoreMembers,
+			FillPolicy: createBlobStoreFillPolicy,
+		}
+	case "azure":
+		input.Type = nexus.BlobStoreTypeAzure
+		input.AzureConfig = &nexus.AzureBlobStoreConfig{
+			AccountName:          createBlobStoreAccountName,
+			ContainerName:        createBlobStoreContainer,
+			AuthenticationMethod: createBlobStoreAuthMethod,
+		}
+		input.AzureConfig.AccountKey = optional(createBlobStoreAccountKey)
+	case "google":
+		input.Type = nexus.BlobStoreTypeGoogle
+		input.GoogleConfig = &nexus.GoogleBlobStoreConfig{
+			Bucket:         createBlobStoreBucket,
+			Prefix:         optional(createBlobStorePrefix),
+			Region:         optional(createBlobStoreRegion),
+			CredentialFile: optional(createBlobStoreCredentialFile),
+		}
+	}
+	store, err := client.CreateBlobStore(input)
 	checkErr(err)
 	out, err := json.MarshalIndent(store, "", "    ")
 	checkErr(err)
 	fmt.Println(string(out))
 }
+
+// optional returns nil for flags that were not given so they are left out of the request
+func optional(flag *string) *string {
+	if *flag == "" {
+		return nil
+	}
+	return flag
+}
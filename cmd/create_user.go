@@ -0,0 +1,26 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	nexus "github.com/tinyzimmer/nexus3-go"
+)
+
+func createUser() {
+	client, err := newClient()
+	checkErr(err)
+	user, err := client.CreateUser(&nexus.User{
+		UserID:       createUserID,
+		FirstName:    createUserFirstName,
+		LastName:     createUserLastName,
+		EmailAddress: createUserEmail,
+		Password:     createUserPassword,
+		Status:       createUserStatus,
+		Roles:        *createUserRoles,
+	})
+	checkErr(err)
+	out, err := json.MarshalIndent(user, "", "    ")
+	checkErr(err)
+	fmt.Println(string(out))
+}
@@ -7,7 +7,7 @@ import (
 )
 
 func deleteBlobStore() {
-	client, err := nexus.New(*host, *username, *password)
+	client, err := newClient()
 	checkErr(err)
 	err = client.DeleteBlobStore(&nexus.DeleteBlobStoreInput{
 		Name: deleteBlobStoreName,
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"fmt"
+)
+
+func deleteUser() {
+	client, err := newClient()
+	checkErr(err)
+	err = client.DeleteUser(*deleteUserID)
+	checkErr(err)
+	fmt.Printf("User %s deleted\n", *deleteUserID)
+}
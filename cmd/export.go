@@ -0,0 +1,29 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"sigs.k8s.io/yaml"
+)
+
+func exportConfig() {
+	client, err := newClient()
+	checkErr(err)
+	snapshot, err := client.Export()
+	checkErr(err)
+	var out []byte
+	if *exportFormat == "json" {
+		out, err = json.MarshalIndent(snapshot, "", "    ")
+	} else {
+		out, err = yaml.Marshal(snapshot)
+	}
+	checkErr(err)
+	if *exportOutput == "" {
+		fmt.Println(string(out))
+		return
+	}
+	checkErr(ioutil.WriteFile(*exportOutput, out, 0600))
+	fmt.Printf("Configuration exported to %s\n", *exportOutput)
+}
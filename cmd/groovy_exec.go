@@ -25,7 +25,7 @@ func executeScript() {
 	} else {
 		checkErr(errors.New("You must provide either a script file or a command to execute"))
 	}
-	client, err := nexus.New(*host, *username, *password)
+	client, err := newClient()
 	checkErr(err)
 	script := client.NewEphemeralScript(&nexus.Script{
 		Type:    nexus.ScriptTypeGroovy,
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	nexus "github.com/tinyzimmer/nexus3-go"
+	"sigs.k8s.io/yaml"
+)
+
+func importConfig() {
+	body, err := ioutil.ReadFile(*importFile)
+	checkErr(err)
+	snapshot := &nexus.Snapshot{}
+	checkErr(yaml.Unmarshal(body, snapshot))
+	input := &nexus.ImportInput{
+		Snapshot: snapshot,
+		DryRun:   importDryRun,
+	}
+	if *importUserPassword != "" {
+		input.UserPassword = importUserPassword
+	}
+	client, err := newClient()
+	checkErr(err)
+	plan, err := client.Import(input)
+	if plan != nil {
+		fmt.Print(plan)
+	}
+	checkErr(err)
+	if !*importDryRun && !plan.Empty() {
+		fmt.Println("Import complete")
+	}
+}
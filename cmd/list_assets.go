@@ -8,7 +8,7 @@ import (
 )
 
 func listAssets() {
-	client, err := nexus.New(*host, *username, *password)
+	client, err := newClient()
 	checkErr(err)
 	input := &nexus.ListAssetsInput{
 		Repository: listAssetsRepo,
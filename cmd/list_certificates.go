@@ -0,0 +1,16 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+func listCertificates() {
+	client, err := newClient()
+	checkErr(err)
+	res, err := client.ListTrustedCertificates()
+	checkErr(err)
+	out, err := json.MarshalIndent(res, "", "    ")
+	checkErr(err)
+	fmt.Println(string(out))
+}
@@ -8,7 +8,7 @@ import (
 )
 
 func listComponents() {
-	client, err := nexus.New(*host, *username, *password)
+	client, err := newClient()
 	checkErr(err)
 	input := &nexus.ListComponentsInput{
 		Repository: listComponentsRepo,
@@ -3,12 +3,10 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-
-	nexus "github.com/tinyzimmer/nexus3-go"
 )
 
 func listFormats() {
-	client, err := nexus.New(*host, *username, *password)
+	client, err := newClient()
 	checkErr(err)
 	res, err := client.ListFormats()
 	checkErr(err)
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	nexus "github.com/tinyzimmer/nexus3-go"
+)
+
+func listTasks() {
+	client, err := newClient()
+	checkErr(err)
+	input := &nexus.ListTasksInput{}
+	if *listTasksType != "" {
+		input.Type = listTasksType
+	}
+	res, err := client.ListTasks(input)
+	checkErr(err)
+	out, err := json.MarshalIndent(res, "", "    ")
+	checkErr(err)
+	fmt.Println(string(out))
+}
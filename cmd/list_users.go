@@ -0,0 +1,25 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	nexus "github.com/tinyzimmer/nexus3-go"
+)
+
+func listUsers() {
+	client, err := newClient()
+	checkErr(err)
+	input := &nexus.ListUsersInput{}
+	if *listUsersSource != "" {
+		input.Source = listUsersSource
+	}
+	if *listUsersID != "" {
+		input.UserID = listUsersID
+	}
+	res, err := client.ListUsers(input)
+	checkErr(err)
+	out, err := json.MarshalIndent(res, "", "    ")
+	checkErr(err)
+	fmt.Println(string(out))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	nexus "github.com/tinyzimmer/nexus3-go"
 	kingpin "gopkg.in/alecthomas/kingpin.v2"
 )
 
@@ -13,6 +14,10 @@ var (
 	host     = app.Flag("host", "URL of the Nexus host").Short('h').Default("http://localhost:8081").String()
 	username = app.Flag("username", "Username to authenticate to Nexus").Short('u').Default("admin").String()
 	password = app.Flag("password", "Password to authenticate to Nexus").Short('p').Default("admin123").String()
+	auth     = app.Flag("auth", "How to authenticate to Nexus: basic, token, netrc, env or anonymous").Default("basic").Enum("basic", "token", "netrc", "env", "anonymous")
+	caCert   = app.Flag("ca-cert", "A PEM encoded CA bundle to trust when connecting to Nexus").ExistingFile()
+	proxy    = app.Flag("proxy", "URL of an HTTP proxy to connect through").String()
+	timeout  = app.Flag("timeout", "Time limit for connecting to Nexus and for each response to start").Default("0s").Duration()
 
 	scriptExec = app.Command("groovy-exec", "Execute a groovy script on the Nexus host")
 	scriptArgs = scriptExec.Arg("commands", "Groovy commands to execute").Strings()
@@ -21,6 +26,7 @@ var (
 	listReposCmd      = app.Command("list-repositories", "List the repositories in Nexus")
 	listBlobStoresCmd = app.Command("list-blob-stores", "List the blob stores in Nexus")
 	listFormatsCmd    = app.Command("list-formats", "List the available component formats")
+	blobStoreUsageCmd = app.Command("blobstore-usage", "Show the usage of each blob store, fullest first")
 
 	listAssetsCmd  = app.Command("list-assets", "List the assets for a given repository")
 	listAssetsRepo = listAssetsCmd.Arg("repository", "The repository to list assets for").Required().String()
@@ -28,6 +34,11 @@ var (
 	listComponentsCmd  = app.Command("list-components", "List the components for a given repository")
 	listComponentsRepo = listComponentsCmd.Arg("repository", "The repository to list components for").Required().String()
 
+	testSelectorCmd        = app.Command("test-content-selector", "List the assets of a repository matched by a content selector expression")
+	testSelectorRepo       = testSelectorCmd.Arg("repository", "The repository to match assets in").Required().String()
+	testSelectorExpression = testSelectorCmd.Flag("expression", "The CSEL expression to test").Short('e').String()
+	testSelectorName       = testSelectorCmd.Flag("selector", "The name of an existing content selector to test").String()
+
 	uploadComponentCmd  = app.Command("upload-component", "Upload a component to a given repository")
 	uploadComponentRepo = uploadComponentCmd.Flag("repository", "The repository to upload the component to").Short('r').Required().String()
 	uploadComponentType = uploadComponentCmd.Flag("type", "The type of the component").Short('t').Required().String()
@@ -35,21 +46,112 @@ var (
 
 	createBlobStoreCmd             = app.Command("create-blobstore", "Create a new blob store")
 	createBlobStoreName            = createBlobStoreCmd.Flag("name", "The name of the blobstore").Short('n').Required().String()
-	createBlobStoreType            = createBlobStoreCmd.Flag("type", "The type of the blob store").Short('t').Default("file").Enum("file", "s3")
+	createBlobStoreType            = createBlobStoreCmd.Flag("type", "The type of the blob store").Short('t').Default("file").Enum("file", "s3", "group", "azure", "google")
 	createBlobStorePath            = createBlobStoreCmd.Flag("path", "The path to the blob store when type is file").String()
-	createBlobStoreBucket          = createBlobStoreCmd.Flag("bucket", "The s3 bucket when creating an s3 blob store").String()
-	createBlobStorePrefix          = createBlobStoreCmd.Flag("prefix", "The s3 bucket prefix for the blob store").String()
+	createBlobStoreBucket          = createBlobStoreCmd.Flag("bucket", "The bucket when creating an s3 or google blob store").String()
+	createBlobStorePrefix          = createBlobStoreCmd.Flag("prefix", "The bucket prefix for the blob store").String()
 	createBlobStoreAccessKeyID     = createBlobStoreCmd.Flag("access-key-id", "The AWS IAM AccessKeyID").String()
 	createBlobStoreSecretAccessKey = createBlobStoreCmd.Flag("secret-access-key", "The AWS IAM SecretAccessKey").String()
 	createBlobStoreAssumeRole      = createBlobStoreCmd.Flag("assume-role", "The AWS IAM Role to assume").String()
-	createBlobStoreRegion          = createBlobStoreCmd.Flag("region", "The AWS region to use").String()
+	createBlobStoreRegion          = createBlobStoreCmd.Flag("region", "The AWS or Google Cloud region to use").String()
 	createBlobStoreExpiration      = createBlobStoreCmd.Flag("expiry-days", "The number of days to wait to expire deleted blobs").Default("-1").Int()
+	createBlobStoreEndpoint        = createBlobStoreCmd.Flag("endpoint", "A custom S3 endpoint, such as a MinIO server").String()
+	createBlobStoreSessionToken    = createBlobStoreCmd.Flag("session-token", "The AWS session token for temporary credentials").String()
+	createBlobStoreForcePathStyle  = createBlobStoreCmd.Flag("force-path-style", "Use path-style access to the s3 bucket").Bool()
+	createBlobStoreEncryption      = createBlobStoreCmd.Flag("encryption-type", "Server-side encryption for the s3 bucket").Enum("s3ManagedEncryption", "kmsManagedEncryption")
+	createBlobStoreEncryptionKey   = createBlobStoreCmd.Flag("encryption-key", "The KMS key ID when using kmsManagedEncryption").String()
+	createBlobStoreMaxConnections  = createBlobStoreCmd.Flag("max-connections", "The maximum size of the s3 connection pool").Int()
+	createBlobStoreMembers         = createBlobStoreCmd.Flag("member", "A member of a group blob store, may be repeated").Strings()
+	createBlobStoreFillPolicy      = createBlobStoreCmd.Flag("fill-policy", "The fill policy of a group blob store").Default("roundRobin").Enum("roundRobin", "writeToFirst")
+	createBlobStoreAccountName     = createBlobStoreCmd.Flag("account-name", "The Azure storage account name").String()
+	createBlobStoreContainer       = createBlobStoreCmd.Flag("container", "The Azure container when creating an azure blob store").String()
+	createBlobStoreAuthMethod      = createBlobStoreCmd.Flag("auth-method", "How Nexus authenticates to Azure").Default("ACCOUNTKEY").Enum("ACCOUNTKEY", "MANAGEDIDENTITY", "ENVIRONMENTVARIABLE")
+	createBlobStoreAccountKey      = createBlobStoreCmd.Flag("account-key", "The Azure storage account key").String()
+	createBlobStoreCredentialFile  = createBlobStoreCmd.Flag("credential-file", "A Google service account key file, defaults to the application default credentials").ExistingFile()
 
 	deleteBlobStoreCmd   = app.Command("delete-blobstore", "Delete a blobstore by the given name")
 	deleteBlobStoreName  = deleteBlobStoreCmd.Arg("blobstore", "The name of the blob store to delete").String()
 	deleteBlobStoreForce = deleteBlobStoreCmd.Flag("force", "Force deletion of an in-use blobstore").Bool()
+
+	promoteBlobStoreCmd  = app.Command("promote-blobstore", "Convert a blob store into a group containing it")
+	promoteBlobStoreName = promoteBlobStoreCmd.Arg("blobstore", "The name of the blob store to promote").Required().String()
+
+	listUsersCmd    = app.Command("list-users", "List the users in Nexus")
+	listUsersSource = listUsersCmd.Flag("source", "Only list users from this source, such as default or LDAP").String()
+	listUsersID     = listUsersCmd.Flag("user-id", "Only list users whose ID starts with this value").String()
+
+	createUserCmd       = app.Command("create-user", "Create a new user")
+	createUserID        = createUserCmd.Flag("user-id", "The ID the user logs in with").Required().String()
+	createUserFirstName = createUserCmd.Flag("first-name", "The first name of the user").Required().String()
+	createUserLastName  = createUserCmd.Flag("last-name", "The last name of the user").Required().String()
+	createUserEmail     = createUserCmd.Flag("email", "The email address of the user").Required().String()
+	createUserPassword  = createUserCmd.Flag("user-password", "The password of the user").Required().String()
+	createUserRoles     = createUserCmd.Flag("role", "A role to grant the user, may be repeated").Required().Strings()
+	createUserStatus    = createUserCmd.Flag("status", "The status of the user").Default("active").Enum("active", "locked", "disabled", "changepassword")
+
+	deleteUserCmd = app.Command("delete-user", "Delete a user by the given ID")
+	deleteUserID  = deleteUserCmd.Arg("user-id", "The ID of the user to delete").Required().String()
+
+	listCertificatesCmd = app.Command("list-certificates", "List the certificates in the Nexus truststore")
+
+	trustCertificateCmd  = app.Command("trust-certificate", "Add the certificate of a remote host to the Nexus truststore")
+	trustCertificateHost = trustCertificateCmd.Arg("host", "The host to retrieve the certificate from").Required().String()
+	trustCertificatePort = trustCertificateCmd.Flag("port", "The port to connect to on the host").Default("443").Int()
+
+	listTasksCmd  = app.Command("list-tasks", "List the scheduled tasks in Nexus")
+	listTasksType = listTasksCmd.Flag("type", "Only list tasks of this type, such as blobstore.compact").String()
+
+	runTaskCmd         = app.Command("run-task", "Run a scheduled task by the given ID")
+	runTaskID          = runTaskCmd.Arg("id", "The ID of the task to run").Required().String()
+	runTaskWait        = runTaskCmd.Flag("wait", "Wait for the task to finish and fail if it does not succeed").Bool()
+	runTaskWaitTimeout = runTaskCmd.Flag("wait-timeout", "How long to wait for the task to finish, 0 waits forever").Default("0s").Duration()
+
+	applyCmd    = app.Command("apply", "Reconcile blob stores, cleanup policies and repositories with a desired state file")
+	applyFile   = applyCmd.Flag("file", "A YAML or JSON file describing the desired state").Short('f').Required().ExistingFile()
+	applyDryRun = applyCmd.Flag("dry-run", "Print the plan without applying it").Bool()
+	applyPrune  = applyCmd.Flag("prune", "Delete objects that are not in the desired state").Bool()
+
+	exportCmd    = app.Command("export", "Export the configuration of Nexus to a YAML or JSON document")
+	exportOutput = exportCmd.Flag("output", "The file to write to, defaults to stdout").Short('o').String()
+	exportFormat = exportCmd.Flag("format", "The format of the document").Default("yaml").Enum("yaml", "json")
+
+	importCmd          = app.Command("import", "Import a configuration document created by export")
+	importFile         = importCmd.Flag("file", "The YAML or JSON document to import").Short('f').Required().ExistingFile()
+	importDryRun       = importCmd.Flag("dry-run", "Print the plan without applying it").Bool()
+	importUserPassword = importCmd.Flag("user-password", "The initial password for users that do not exist yet").String()
 )
 
+func newClient() (*nexus.Nexus, error) {
+	var authenticator nexus.Authenticator
+	var err error
+	switch *auth {
+	case "token":
+		authenticator = nexus.UserTokenAuth(*username, *password)
+	case "netrc":
+		authenticator, err = nexus.NetrcAuth("")
+	case "env":
+		authenticator, err = nexus.EnvAuth()
+	case "anonymous":
+		authenticator = nexus.AnonymousAuth()
+	default:
+		authenticator = nexus.BasicAuth(*username, *password)
+	}
+	if err != nil {
+		return nil, err
+	}
+	opts := []nexus.Option{
+		nexus.WithAuthenticator(authenticator),
+		nexus.WithTimeout(*timeout),
+	}
+	if *caCert != "" {
+		opts = append(opts, nexus.WithCACertFile(*caCert))
+	}
+	if *proxy != "" {
+		opts = append(opts, nexus.WithProxy(*proxy))
+	}
+	return nexus.NewWithOptions(*host, opts...)
+}
+
 func checkErr(err error) {
 	if err != nil {
 		fmt.Println("Error: ", err.Error())
@@ -67,16 +169,42 @@ func main() {
 		listBlobStores()
 	case listAssetsCmd.FullCommand():
 		listAssets()
+	case testSelectorCmd.FullCommand():
+		testContentSelector()
 	case listComponentsCmd.FullCommand():
 		listComponents()
 	case createBlobStoreCmd.FullCommand():
 		createBlobStore()
 	case deleteBlobStoreCmd.FullCommand():
 		deleteBlobStore()
+	case promoteBlobStoreCmd.FullCommand():
+		promoteBlobStore()
 	case listFormatsCmd.FullCommand():
 		listFormats()
+	case blobStoreUsageCmd.FullCommand():
+		blobStoreUsage()
 	case uploadComponentCmd.FullCommand():
 		uploadComponent()
+	case listUsersCmd.FullCommand():
+		listUsers()
+	case createUserCmd.FullCommand():
+		createUser()
+	case deleteUserCmd.FullCommand():
+		deleteUser()
+	case listCertificatesCmd.FullCommand():
+		listCertificates()
+	case trustCertificateCmd.FullCommand():
+		trustCertificate()
+	case listTasksCmd.FullCommand():
+		listTasks()
+	case runTaskCmd.FullCommand():
+		runTask()
+	case applyCmd.FullCommand():
+		applyState()
+	case exportCmd.FullCommand():
+		exportConfig()
+	case importCmd.FullCommand():
+		importConfig()
 	default:
 		app.Usage(nil)
 		os.Exit(1)
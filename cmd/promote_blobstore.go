@@ -0,0 +1,13 @@
+package main
+
+import (
+	"fmt"
+)
+
+func promoteBlobStore() {
+	client, err := newClient()
+	checkErr(err)
+	err = client.PromoteBlobStoreToGroup(*promoteBlobStoreName)
+	checkErr(err)
+	fmt.Printf("Blobstore %s promoted to a group\n", *promoteBlobStoreName)
+}
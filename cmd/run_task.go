@@ -0,0 +1,19 @@
+package main
+
+import (
+	"fmt"
+)
+
+func runTask() {
+	client, err := newClient()
+	checkErr(err)
+	if !*runTaskWait {
+		err = client.RunTask(*runTaskID)
+		checkErr(err)
+		fmt.Printf("Task %s started\n", *runTaskID)
+		return
+	}
+	res, err := client.RunTaskAndWait(*runTaskID, *runTaskWaitTimeout)
+	checkErr(err)
+	fmt.Printf("Task %s finished\n", *res.Name)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	nexus "github.com/tinyzimmer/nexus3-go"
+)
+
+func testContentSelector() {
+	if (*testSelectorExpression == "") == (*testSelectorName == "") {
+		checkErr(errors.New("Exactly one of --expression or --selector is required"))
+	}
+	client, err := newClient()
+	checkErr(err)
+	expression := *testSelectorExpression
+	if *testSelectorName != "" {
+		selector, err := client.GetContentSelector(*testSelectorName)
+		checkErr(err)
+		expression = *selector.Expression
+	}
+	expr, err := nexus.ParseCSEL(expression)
+	checkErr(err)
+	input := &nexus.ListAssetsInput{
+		Repository: testSelectorRepo,
+	}
+	assets := make([]*nexus.Asset, 0)
+	err = client.ListAssetsPages(input, func(res *nexus.ListAssetsResponse, last bool) (bool, error) {
+		assets = append(assets, expr.FilterAssets(res.Items)...)
+		return true, nil
+	})
+	checkErr(err)
+	out, err := json.MarshalIndent(assets, "", "    ")
+	checkErr(err)
+	fmt.Println(string(out))
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"fmt"
+)
+
+func trustCertificate() {
+	client, err := newClient()
+	checkErr(err)
+	res, err := client.TrustRemoteCertificate(*trustCertificateHost, *trustCertificatePort)
+	checkErr(err)
+	cert, err := res.X509()
+	checkErr(err)
+	fmt.Printf("Trusted %s issued by %s, expires %s\n", cert.Subject.CommonName, cert.Issuer.CommonName, cert.NotAfter.Format("2006-01-02"))
+}
@@ -8,7 +8,7 @@ import (
 )
 
 func uploadComponent() {
-	client, err := nexus.New(*host, *username, *password)
+	client, err := newClient()
 	checkErr(err)
 	file, err := os.Open(*uploadComponentFile)
 	checkErr(err)
@@ -1,27 +1,33 @@
 package nexus
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
-	"mime/multipart"
 	"net/http"
 	"os"
-	"path/filepath"
 )
 
+// UploadComponentInput is used to provide parameters to UploadComponent.
+// If OnProgress is set, it is called as the content of each asset is sent.
 type UploadComponentInput struct {
 	Repository      *string
 	ComponentType   *string
 	ComponentConfig *map[string]string
 	Assets          []*UploadComponentAsset
+	OnProgress      UploadProgressFunc
 }
 
+// UploadComponentAsset is a single file of a component upload. The content is
+// read from either File or Reader. When using Reader, Filename must be provided,
+// otherwise it defaults to the base name of File. Uploads can only be retried
+// when the content implements io.Seeker, as *os.File does.
 type UploadComponentAsset struct {
 	File        *os.File
+	Reader      io.Reader
+	Filename    *string
 	AssetConfig *map[string]string
 }
 
@@ -61,77 +67,26 @@ type ListComponentsInput struct {
 	ContinuationToken *string
 }
 
-func (n *Nexus) newListComponentsReq(input *ListComponentsInput) (req *http.Request, err error) {
+func (n *Nexus) newListComponentsReq(ctx context.Context, input *ListComponentsInput) (req *http.Request, err error) {
 	args := map[string]string{
 		"repository": *input.Repository,
 	}
 	if input.ContinuationToken != nil {
 		args["continuationToken"] = *input.ContinuationToken
 	}
-	req, err = n.NewRequest("GET", "service/rest/v1/components", args, nil, "")
+	req, err = n.NewRequestWithContext(ctx, "GET", "service/rest/v1/components", args, nil, "")
 	return
 }
 
-func (n *Nexus) newGetComponentReq(input *GetComponentInput) (req *http.Request, err error) {
+func (n *Nexus) newGetComponentReq(ctx context.Context, input *GetComponentInput) (req *http.Request, err error) {
 	endpoint := fmt.Sprintf("service/rest/v1/components/%s", *input.ID)
-	req, err = n.NewRequest("GET", endpoint, nil, nil, "")
+	req, err = n.NewRequestWithContext(ctx, "GET", endpoint, nil, nil, "")
 	return
 }
 
-func (n *Nexus) newDeleteComponentReq(input *DeleteComponentInput) (req *http.Request, err error) {
+func (n *Nexus) newDeleteComponentReq(ctx context.Context, input *DeleteComponentInput) (req *http.Request, err error) {
 	endpoint := fmt.Sprintf("service/rest/v1/components/%s", *input.ID)
-	req, err = n.NewRequest("DELETE", endpoint, nil, nil, "")
-	return
-}
-
-func (n *Nexus) newUploadBody(input *UploadComponentInput) (bodyBytes []byte, contentType string, err error) {
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-	defer writer.Close()
-	if input.ComponentConfig != nil {
-		for k, v := range *input.ComponentConfig {
-			key := fmt.Sprintf("%s.%s", *input.ComponentType, k)
-			writer.WriteField(key, v)
-		}
-	}
-	if len(input.Assets) == 1 {
-		asset := input.Assets[0]
-		key := fmt.Sprintf("%s.asset", *input.ComponentType)
-		part, err := writer.CreateFormFile(key, filepath.Base(asset.File.Name()))
-		if err != nil {
-			return nil, "", err
-		}
-		_, err = io.Copy(part, asset.File)
-		if err != nil {
-			return nil, "", err
-		}
-		if asset.AssetConfig != nil {
-			for k, v := range *asset.AssetConfig {
-				key := fmt.Sprintf("%s.asset.%s", *input.ComponentType, k)
-				writer.WriteField(key, v)
-			}
-		}
-	} else {
-		for idx, asset := range input.Assets {
-			key := fmt.Sprintf("%s.asset%v", *input.ComponentType, idx)
-			part, err := writer.CreateFormFile(key, filepath.Base(asset.File.Name()))
-			if err != nil {
-				return nil, "", err
-			}
-			_, err = io.Copy(part, asset.File)
-			if err != nil {
-				return nil, "", err
-			}
-			if asset.AssetConfig != nil {
-				for k, v := range *asset.AssetConfig {
-					key := fmt.Sprintf("%s.asset%v.%s", *input.ComponentType, idx, k)
-					writer.WriteField(key, v)
-				}
-			}
-		}
-	}
-	contentType = writer.FormDataContentType()
-	bodyBytes, _ = ioutil.ReadAll(body)
+	req, err = n.NewRequestWithContext(ctx, "DELETE", endpoint, nil, nil, "")
 	return
 }
 
@@ -155,7 +110,7 @@ func hasAllRequiredFields(present map[string]string, required []string) bool {
 	return true
 }
 
-func (n *Nexus) validateComponentFormat(input *UploadComponentInput) (err error) {
+func (n *Nexus) validateComponentFormat(ctx context.Context, input *UploadComponentInput) (err error) {
 	if input.Assets == nil || len(input.Assets) == 0 {
 		err = errors.New("At least one asset must be provided to upload a component")
 		return
@@ -164,7 +119,17 @@ func (n *Nexus) validateComponentFormat(input *UploadComponentInput) (err error)
 		err = errors.New("ComponentType is required for UploadComponent")
 		return
 	}
-	format, err := n.GetFormat(*input.ComponentType)
+	for _, asset := range input.Assets {
+		if asset.File == nil && asset.Reader == nil {
+			err = errors.New("Every asset must provide either a File or a Reader")
+			return
+		}
+		if asset.File == nil && asset.Filename == nil {
+			err = errors.New("Filename is required for assets uploaded from a Reader")
+			return
+		}
+	}
+	format, err := n.GetFormatWithContext(ctx, *input.ComponentType)
 	if err != nil {
 		return
 	}
@@ -208,25 +173,39 @@ func (n *Nexus) validateComponentFormat(input *UploadComponentInput) (err error)
 	return
 }
 
-func (n *Nexus) newUploadComponentReq(input *UploadComponentInput) (req *http.Request, err error) {
+func (n *Nexus) newUploadComponentReq(ctx context.Context, input *UploadComponentInput) (req *http.Request, err error) {
 	args := map[string]string{
 		"repository": *input.Repository,
 	}
-	body, ctype, err := n.newUploadBody(input)
+	body, err := newUploadBody(input)
 	if err != nil {
 		return
 	}
-	req, err = n.NewRequest("POST", "service/rest/v1/components", args, body, ctype)
+	req, err = n.NewRequestWithContext(ctx, "POST", "service/rest/v1/components", args, nil, body.contentType())
+	if err != nil {
+		return
+	}
+	req.Body, req.ContentLength = body.open(), -1
+	if body.replayable() {
+		req.GetBody = body.replay
+	}
 	return
 }
 
 // UploadComponent uploads a component with the given parameters.
 func (n *Nexus) UploadComponent(input *UploadComponentInput) (err error) {
-	err = n.validateComponentFormat(input)
+	return n.UploadComponentWithContext(context.Background(), input)
+}
+
+// UploadComponentWithContext is the same as UploadComponent with the addition of a context.
+// Cancelling the context aborts the upload.
+func (n *Nexus) UploadComponentWithContext(ctx context.Context, input *UploadComponentInput) (err error) {
+	err = n.validateComponentFormat(ctx, input)
 	if err != nil {
 		return
 	}
-	req, err := n.newUploadComponentReq(input)
+	// Uploads are replayed in full, so they can be retried despite being a POST
+	req, err := n.newUploadComponentReq(withReplayable(ctx), input)
 	if err != nil {
 		return
 	}
@@ -239,11 +218,17 @@ func (n *Nexus) UploadComponent(input *UploadComponentInput) (err error) {
 
 // ListComponents returns a response with up to 10 components and a token to request the next page.
 func (n *Nexus) ListComponents(input *ListComponentsInput) (res *ListComponentsResponse, err error) {
+	return n.ListComponentsWithContext(context.Background(), input)
+}
+
+// ListComponentsWithContext is the same as ListComponents with the addition of a context
+// for cancellation and deadlines.
+func (n *Nexus) ListComponentsWithContext(ctx context.Context, input *ListComponentsInput) (res *ListComponentsResponse, err error) {
 	if input.Repository == nil {
 		err = errors.New("Repository is required for ListComponents")
 		return
 	}
-	req, err := n.newListComponentsReq(input)
+	req, err := n.newListComponentsReq(ctx, input)
 	if err != nil {
 		return
 	}
@@ -271,7 +256,15 @@ func (n *Nexus) ListComponents(input *ListComponentsInput) (res *ListComponentsR
 
 // ListComponentsPages is identical in usage to ListAssetsPages
 func (n *Nexus) ListComponentsPages(input *ListComponentsInput, cb func(res *ListComponentsResponse, last bool) (cont bool, err error)) error {
-	res, err := n.ListComponents(input)
+	return n.ListComponentsPagesWithContext(context.Background(), input, cb)
+}
+
+// ListComponentsPagesWithContext is identical in usage to ListAssetsPagesWithContext
+func (n *Nexus) ListComponentsPagesWithContext(ctx context.Context, input *ListComponentsInput, cb func(res *ListComponentsResponse, last bool) (cont bool, err error)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	res, err := n.ListComponentsWithContext(ctx, input)
 	if err != nil {
 		return err
 	}
@@ -290,16 +283,21 @@ func (n *Nexus) ListComponentsPages(input *ListComponentsInput, cb func(res *Lis
 		Repository:        input.Repository,
 		ContinuationToken: res.ContinuationToken,
 	}
-	return n.ListComponentsPages(newInput, cb)
+	return n.ListComponentsPagesWithContext(ctx, newInput, cb)
 }
 
 // GetComponent retrieves a component by the given ID.
 func (n *Nexus) GetComponent(input *GetComponentInput) (res *Component, err error) {
+	return n.GetComponentWithContext(context.Background(), input)
+}
+
+// GetComponentWithContext is the same as GetComponent with the addition of a context
+func (n *Nexus) GetComponentWithContext(ctx context.Context, input *GetComponentInput) (res *Component, err error) {
 	if input.ID == nil {
 		err = errors.New("Component ID is required for GetAsset")
 		return
 	}
-	req, err := n.newGetComponentReq(input)
+	req, err := n.newGetComponentReq(ctx, input)
 	if err != nil {
 		return
 	}
@@ -321,11 +319,16 @@ func (n *Nexus) GetComponent(input *GetComponentInput) (res *Component, err erro
 
 // DeleteComponent removes a component with the given ID.
 func (n *Nexus) DeleteComponent(input *DeleteComponentInput) (err error) {
+	return n.DeleteComponentWithContext(context.Background(), input)
+}
+
+// DeleteComponentWithContext is the same as DeleteComponent with the addition of a context
+func (n *Nexus) DeleteComponentWithContext(ctx context.Context, input *DeleteComponentInput) (err error) {
 	if input.ID == nil {
 		err = errors.New("Component ID is required for DeleteComponent")
 		return
 	}
-	req, err := n.newDeleteComponentReq(input)
+	req, err := n.newDeleteComponentReq(ctx, input)
 	if err != nil {
 		return
 	}
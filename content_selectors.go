@@ -0,0 +1,142 @@
+package nexus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ContentSelector represents a content selector, which uses a CSEL expression
+// to select the assets a repository-content-selector privilege applies to.
+type ContentSelector struct {
+	Name        *string `json:"name"`
+	Type        *string `json:"type,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Expression  *string `json:"expression"`
+}
+
+// ListContentSelectors returns the content selectors configured in Nexus
+func (n *Nexus) ListContentSelectors() (res []*ContentSelector, err error) {
+	return n.ListContentSelectorsWithContext(context.Background())
+}
+
+// ListContentSelectorsWithContext is the same as ListContentSelectors with the addition of a context
+func (n *Nexus) ListContentSelectorsWithContext(ctx context.Context) (res []*ContentSelector, err error) {
+	res = make([]*ContentSelector, 0)
+	req, err := n.NewRequestWithContext(ctx, "GET", "service/rest/v1/security/content-selectors", nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: "Insufficient permissions to list content selectors",
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// CreateContentSelector creates a new content selector. Name and Expression are required.
+func (n *Nexus) CreateContentSelector(selector *ContentSelector) (err error) {
+	return n.CreateContentSelectorWithContext(context.Background(), selector)
+}
+
+// CreateContentSelectorWithContext is the same as CreateContentSelector with the addition of a context
+func (n *Nexus) CreateContentSelectorWithContext(ctx context.Context, selector *ContentSelector) (err error) {
+	if selector.Name == nil || selector.Expression == nil {
+		err = errors.New("Name and Expression are required for CreateContentSelector")
+		return
+	}
+	payload, err := json.Marshal(&ContentSelector{
+		Name:        selector.Name,
+		Description: selector.Description,
+		Expression:  selector.Expression,
+	})
+	if err != nil {
+		return
+	}
+	req, err := n.NewRequestWithContext(ctx, "POST", "service/rest/v1/security/content-selectors", nil, payload, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to create content selector %s", *selector.Name),
+	}, false)
+	return
+}
+
+// UpdateContentSelector replaces the description and expression of an existing content selector
+func (n *Nexus) UpdateContentSelector(selector *ContentSelector) (err error) {
+	return n.UpdateContentSelectorWithContext(context.Background(), selector)
+}
+
+// UpdateContentSelectorWithContext is the same as UpdateContentSelector with the addition of a context
+func (n *Nexus) UpdateContentSelectorWithContext(ctx context.Context, selector *ContentSelector) (err error) {
+	if selector.Name == nil || selector.Expression == nil {
+		err = errors.New("Name and Expression are required for UpdateContentSelector")
+		return
+	}
+	payload, err := json.Marshal(map[string]*string{
+		"description": selector.Description,
+		"expression":  selector.Expression,
+	})
+	if err != nil {
+		return
+	}
+	endpoint := fmt.Sprintf("service/rest/v1/security/content-selectors/%s", *selector.Name)
+	req, err := n.NewRequestWithContext(ctx, "PUT", endpoint, nil, payload, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to update content selector %s", *selector.Name),
+		404: fmt.Sprintf("Content selector %s does not exist", *selector.Name),
+	}, false)
+	return
+}
+
+// GetContentSelector retrieves a content selector by name
+func (n *Nexus) GetContentSelector(name string) (res *ContentSelector, err error) {
+	return n.GetContentSelectorWithContext(context.Background(), name)
+}
+
+// GetContentSelectorWithContext is the same as GetContentSelector with the addition of a context
+func (n *Nexus) GetContentSelectorWithContext(ctx context.Context, name string) (res *ContentSelector, err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/security/content-selectors/%s", name)
+	req, err := n.NewRequestWithContext(ctx, "GET", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to get content selector %s", name),
+		404: fmt.Sprintf("Content selector %s does not exist", name),
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// DeleteContentSelector removes the content selector with the given name. Nexus
+// refuses to delete a selector that is still used by a privilege.
+func (n *Nexus) DeleteContentSelector(name string) (err error) {
+	return n.DeleteContentSelectorWithContext(context.Background(), name)
+}
+
+// DeleteContentSelectorWithContext is the same as DeleteContentSelector with the addition of a context
+func (n *Nexus) DeleteContentSelectorWithContext(ctx context.Context, name string) (err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/security/content-selectors/%s", name)
+	req, err := n.NewRequestWithContext(ctx, "DELETE", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		400: fmt.Sprintf("Content selector %s is in use and cannot be deleted", name),
+		403: fmt.Sprintf("Insufficient permissions to delete content selector %s", name),
+		404: fmt.Sprintf("Content selector %s does not exist", name),
+	}, false)
+	return
+}
@@ -0,0 +1,420 @@
+package nexus
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CSELExpression is a parsed content selector (CSEL) expression. It can be
+// evaluated locally to check which assets a content selector would match before
+// it is created in Nexus.
+//
+// The supported syntax is the subset of JEXL accepted by Nexus. Comparisons take
+// the form <identifier> <operator> "<value>", where the identifier is format, path
+// or coordinate.<name> and the operator is one of:
+//
+//     ==  equals
+//     !=  does not equal
+//     =^  starts with
+//     =~  matches the regular expression, which must match the entire value
+//
+// Comparisons can be combined with and, or, not and parentheses. The &&, || and !
+// forms of the logical operators are accepted as well.
+type CSELExpression struct {
+	expression string
+	root       cselNode
+}
+
+// CSELSyntaxError is returned when a CSEL expression cannot be parsed. Position
+// is the byte offset into the expression where the problem was found.
+type CSELSyntaxError struct {
+	Expression string
+	Position   int
+	Message    string
+}
+
+// Error implements the error interface
+func (e *CSELSyntaxError) Error() string {
+	return fmt.Sprintf("Invalid CSEL expression at position %d: %s", e.Position, e.Message)
+}
+
+// ParseCSEL parses and validates a CSEL expression
+//
+// Example
+//
+// Check which assets of a repository a selector would match
+//
+//     expr, err := nexus.ParseCSEL(`format == "maven2" and path =^ "/com/acme/"`)
+//     if err != nil {
+//         log.Fatal(err)
+//     }
+//     res, err := client.ListAssets(&nexus.ListAssetsInput{Repository: nexus.String("maven-releases")})
+//     if err != nil {
+//         log.Fatal(err)
+//     }
+//     for _, asset := range expr.FilterAssets(res.Items) {
+//         fmt.Println(*asset.Path)
+//     }
+func ParseCSEL(expression string) (*CSELExpression, error) {
+	p := &cselParser{expression: expression}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != cselTokenEOF {
+		return nil, p.errorf(tok.pos, "unexpected %s", tok)
+	}
+	return &CSELExpression{expression: expression, root: root}, nil
+}
+
+// ValidateCSEL returns an error if the given CSEL expression would not be accepted
+// by Nexus. Note that regular expressions are validated with Go's regexp package,
+// which does not support every construct of the Java patterns used by Nexus.
+func ValidateCSEL(expression string) error {
+	_, err := ParseCSEL(expression)
+	return err
+}
+
+// String returns the original expression
+func (e *CSELExpression) String() string {
+	return e.expression
+}
+
+// Evaluate returns whether the expression matches the given values. The keys of
+// values are the identifiers used in the expression, such as "format", "path" or
+// "coordinate.groupId". Comparisons against an identifier that is not present
+// never match.
+func (e *CSELExpression) Evaluate(values map[string]string) bool {
+	return e.root.eval(values)
+}
+
+// MatchesAsset returns whether the expression matches the path and format of the
+// given asset. Nexus evaluates selectors against paths with a leading slash, so
+// one is added to the asset path when it is missing.
+func (e *CSELExpression) MatchesAsset(asset *Asset) bool {
+	return e.Evaluate(asset.selectorValues())
+}
+
+// FilterAssets returns the assets that are matched by the expression
+func (e *CSELExpression) FilterAssets(assets []*Asset) []*Asset {
+	res := make([]*Asset, 0)
+	for _, asset := range assets {
+		if e.MatchesAsset(asset) {
+			res = append(res, asset)
+		}
+	}
+	return res
+}
+
+func (a *Asset) selectorValues() map[string]string {
+	values := make(map[string]string)
+	if a.Path != nil {
+		path := *a.Path
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		values["path"] = path
+	}
+	if a.Format != nil {
+		values["format"] = *a.Format
+	}
+	return values
+}
+
+type cselNode interface {
+	eval(values map[string]string) bool
+}
+
+type cselAnd struct{ left, right cselNode }
+
+func (n *cselAnd) eval(values map[string]string) bool {
+	return n.left.eval(values) && n.right.eval(values)
+}
+
+type cselOr struct{ left, right cselNode }
+
+func (n *cselOr) eval(values map[string]string) bool {
+	return n.left.eval(values) || n.right.eval(values)
+}
+
+type cselNot struct{ node cselNode }
+
+func (n *cselNot) eval(values map[string]string) bool {
+	return !n.node.eval(values)
+}
+
+type cselComparison struct {
+	identifier string
+	operator   string
+	value      string
+	regex      *regexp.Regexp
+}
+
+func (n *cselComparison) eval(values map[string]string) bool {
+	actual, ok := values[n.identifier]
+	if !ok {
+		return false
+	}
+	switch n.operator {
+	case "==":
+		return actual == n.value
+	case "!=":
+		return actual != n.value
+	case "=^":
+		return strings.HasPrefix(actual, n.value)
+	case "=~":
+		return n.regex.MatchString(actual)
+	}
+	return false
+}
+
+type cselTokenKind int
+
+const (
+	cselTokenEOF cselTokenKind = iota
+	cselTokenIdentifier
+	cselTokenString
+	cselTokenOperator
+	cselTokenAnd
+	cselTokenOr
+	cselTokenNot
+	cselTokenLeftParen
+	cselTokenRightParen
+)
+
+type cselToken struct {
+	kind  cselTokenKind
+	value string
+	pos   int
+}
+
+func (t cselToken) String() string {
+	switch t.kind {
+	case cselTokenEOF:
+		return "end of expression"
+	case cselTokenString:
+		return fmt.Sprintf("string %q", t.value)
+	}
+	return fmt.Sprintf("%q", t.value)
+}
+
+type cselParser struct {
+	expression string
+	tokens     []cselToken
+	next       int
+}
+
+func (p *cselParser) errorf(pos int, format string, args ...interface{}) error {
+	return &CSELSyntaxError{
+		Expression: p.expression,
+		Position:   pos,
+		Message:    fmt.Sprintf(format, args...),
+	}
+}
+
+func (p *cselParser) peek() cselToken {
+	return p.tokens[p.next]
+}
+
+func (p *cselParser) consume() cselToken {
+	tok := p.tokens[p.next]
+	if tok.kind != cselTokenEOF {
+		p.next++
+	}
+	return tok
+}
+
+func isCSELIdentifierChar(c byte, first bool) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_':
+		return true
+	case c >= '0' && c <= '9', c == '.':
+		return !first
+	}
+	return false
+}
+
+func (p *cselParser) tokenize() error {
+	s := p.expression
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			p.tokens = append(p.tokens, cselToken{kind: cselTokenLeftParen, value: "(", pos: i})
+			i++
+		case c == ')':
+			p.tokens = append(p.tokens, cselToken{kind: cselTokenRightParen, value: ")", pos: i})
+			i++
+		case c == '"' || c == '\'':
+			start := i
+			var value strings.Builder
+			i++
+			for {
+				if i >= len(s) {
+					return p.errorf(start, "unterminated string")
+				}
+				if s[i] == c {
+					i++
+					break
+				}
+				// Like JEXL, only an escaped quote or backslash loses its backslash,
+				// so regular expressions such as "\." keep their meaning
+				if s[i] == '\\' && i+1 < len(s) && (s[i+1] == c || s[i+1] == '\\') {
+					i++
+				}
+				value.WriteByte(s[i])
+				i++
+			}
+			p.tokens = append(p.tokens, cselToken{kind: cselTokenString, value: value.String(), pos: start})
+		case c == '=' || c == '!':
+			if i+1 < len(s) {
+				op := s[i : i+2]
+				switch op {
+				case "==", "!=", "=^", "=~":
+					p.tokens = append(p.tokens, cselToken{kind: cselTokenOperator, value: op, pos: i})
+					i += 2
+					continue
+				}
+			}
+			if c == '!' {
+				p.tokens = append(p.tokens, cselToken{kind: cselTokenNot, value: "!", pos: i})
+				i++
+				continue
+			}
+			return p.errorf(i, "unsupported operator, expected ==, !=, =^ or =~")
+		case c == '&' || c == '|':
+			if i+1 >= len(s) || s[i+1] != c {
+				return p.errorf(i, "unexpected character %q", c)
+			}
+			kind := cselTokenAnd
+			if c == '|' {
+				kind = cselTokenOr
+			}
+			p.tokens = append(p.tokens, cselToken{kind: kind, value: s[i : i+2], pos: i})
+			i += 2
+		case isCSELIdentifierChar(c, true):
+			start := i
+			for i < len(s) && isCSELIdentifierChar(s[i], false) {
+				i++
+			}
+			word := s[start:i]
+			kind := cselTokenIdentifier
+			switch word {
+			case "and":
+				kind = cselTokenAnd
+			case "or":
+				kind = cselTokenOr
+			case "not":
+				kind = cselTokenNot
+			}
+			p.tokens = append(p.tokens, cselToken{kind: kind, value: word, pos: start})
+		default:
+			return p.errorf(i, "unexpected character %q", c)
+		}
+	}
+	p.tokens = append(p.tokens, cselToken{kind: cselTokenEOF, pos: len(s)})
+	return nil
+}
+
+func (p *cselParser) parseOr() (cselNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == cselTokenOr {
+		p.consume()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &cselOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *cselParser) parseAnd() (cselNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == cselTokenAnd {
+		p.consume()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &cselAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *cselParser) parseUnary() (cselNode, error) {
+	tok := p.consume()
+	switch tok.kind {
+	case cselTokenNot:
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &cselNot{node: node}, nil
+	case cselTokenLeftParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.consume(); closing.kind != cselTokenRightParen {
+			return nil, p.errorf(closing.pos, "expected \")\" to close \"(\" at position %d, found %s", tok.pos, closing)
+		}
+		return node, nil
+	case cselTokenIdentifier:
+		return p.parseComparison(tok)
+	}
+	return nil, p.errorf(tok.pos, "expected a comparison, found %s", tok)
+}
+
+func (p *cselParser) parseComparison(identifier cselToken) (cselNode, error) {
+	if err := p.validateIdentifier(identifier); err != nil {
+		return nil, err
+	}
+	op := p.consume()
+	if op.kind != cselTokenOperator {
+		return nil, p.errorf(op.pos, "expected ==, !=, =^ or =~ after %s, found %s", identifier, op)
+	}
+	value := p.consume()
+	if value.kind != cselTokenString {
+		return nil, p.errorf(value.pos, "expected a quoted string after %s, found %s", op, value)
+	}
+	node := &cselComparison{
+		identifier: identifier.value,
+		operator:   op.value,
+		value:      value.value,
+	}
+	if op.value == "=~" {
+		if _, err := regexp.Compile(value.value); err != nil {
+			return nil, p.errorf(value.pos, "invalid regular expression: %s", err.Error())
+		}
+		// Nexus requires the pattern to match the entire value
+		node.regex = regexp.MustCompile("^(?:" + value.value + ")$")
+	}
+	return node, nil
+}
+
+func (p *cselParser) validateIdentifier(tok cselToken) error {
+	switch {
+	case tok.value == "format", tok.value == "path":
+		return nil
+	case strings.HasPrefix(tok.value, "coordinate."):
+		name := strings.TrimPrefix(tok.value, "coordinate.")
+		if name != "" && !strings.Contains(name, ".") {
+			return nil
+		}
+	}
+	return p.errorf(tok.pos, "unknown identifier %s, expected format, path or coordinate.<name>", tok)
+}
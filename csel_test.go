@@ -0,0 +1,134 @@
+package nexus
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseCSEL(t *testing.T) {
+	tests := []struct {
+		expression string
+		valid      bool
+		position   int
+	}{
+		{`format == "maven2"`, true, 0},
+		{`format == 'maven2'`, true, 0},
+		{`format == "maven2" and path =^ "/com/acme/"`, true, 0},
+		{`format == "npm" or (format == "maven2" && path =~ "/org/.*")`, true, 0},
+		{`not format != "raw"`, true, 0},
+		{`!(path =^ "/tmp/") || coordinate.groupId == "com.acme"`, true, 0},
+		{`path =~ "^/com/acme\..*"`, true, 0},
+		{``, false, 0},
+		{`format = "maven2"`, false, 7},
+		{`foo == "maven2"`, false, 0},
+		{`coordinate. == "x"`, false, 0},
+		{`format == maven2`, false, 10},
+		{`(format == "maven2"`, false, 19},
+		{`format == "maven2")`, false, 18},
+		{`format == "maven2" and`, false, 22},
+		{`format == "maven2`, false, 10},
+		{`path =~ "("`, false, 8},
+		{`format == "a" & path == "b"`, false, 14},
+	}
+	for _, tt := range tests {
+		_, err := ParseCSEL(tt.expression)
+		if tt.valid {
+			if err != nil {
+				t.Errorf("ParseCSEL(%q) returned %v", tt.expression, err)
+			}
+			continue
+		}
+		var syntaxErr *CSELSyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("ParseCSEL(%q) returned %v, expected a syntax error", tt.expression, err)
+			continue
+		}
+		if syntaxErr.Position != tt.position {
+			t.Errorf("ParseCSEL(%q) reported position %d, expected %d: %v", tt.expression, syntaxErr.Position, tt.position, err)
+		}
+	}
+}
+
+func TestCSELMatchesAsset(t *testing.T) {
+	acmeJar := &Asset{Path: String("com/acme/app/1.0/app-1.0.jar"), Format: String("maven2")}
+	acmePom := &Asset{Path: String("/com/acme/app/1.0/app-1.0.pom"), Format: String("maven2")}
+	otherJar := &Asset{Path: String("org/other/lib/2.0/lib-2.0.jar"), Format: String("maven2")}
+	acmeNpm := &Asset{Path: String("@acme/ui/-/ui-1.0.0.tgz"), Format: String("npm")}
+	lookalike := &Asset{Path: String("com/acmeX/app/1.0/app-1.0.jar"), Format: String("maven2")}
+	assets := []*Asset{acmeJar, acmePom, otherJar, acmeNpm, lookalike}
+
+	tests := []struct {
+		expression string
+		matches    []*Asset
+	}{
+		{`format == "maven2" and path =^ "/com/acme/"`, []*Asset{acmeJar, acmePom}},
+		{`format == "maven2" or path =^ "/@acme/"`, []*Asset{acmeJar, acmePom, otherJar, acmeNpm, lookalike}},
+		{`format == "npm" or format == "maven2" and path =^ "/org/"`, []*Asset{otherJar, acmeNpm}},
+		{`(format == "npm" or format == "maven2") and path =^ "/org/"`, []*Asset{otherJar}},
+		{`not (format == 'npm')`, []*Asset{acmeJar, acmePom, otherJar, lookalike}},
+		{`format != "maven2"`, []*Asset{acmeNpm}},
+		{`path =~ ".*\.jar"`, []*Asset{acmeJar, otherJar, lookalike}},
+		{`path =~ "/com/acme\..*"`, []*Asset{}},
+		{`path =~ "/com/acme./.*"`, []*Asset{lookalike}},
+		{`path =~ "/com/acme/.*\.pom"`, []*Asset{acmePom}},
+		{`path =~ "/com/acme"`, []*Asset{}},
+		{`path == "/org/other/lib/2.0/lib-2.0.jar"`, []*Asset{otherJar}},
+		{`coordinate.groupId == "com.acme"`, []*Asset{}},
+	}
+	for _, tt := range tests {
+		expr, err := ParseCSEL(tt.expression)
+		if err != nil {
+			t.Fatalf("ParseCSEL(%q) returned %v", tt.expression, err)
+		}
+		got := expr.FilterAssets(assets)
+		if len(got) != len(tt.matches) {
+			t.Errorf("%q matched %d assets, expected %d", tt.expression, len(got), len(tt.matches))
+			continue
+		}
+		for idx := range got {
+			if got[idx] != tt.matches[idx] {
+				t.Errorf("%q matched %s, expected %s", tt.expression, *got[idx].Path, *tt.matches[idx].Path)
+			}
+		}
+	}
+}
+
+func TestCSELStringEscapes(t *testing.T) {
+	tests := []struct {
+		expression string
+		value      string
+		matches    bool
+	}{
+		{`path == "/a\"b"`, `/a"b`, true},
+		{`path == '/a\'b'`, `/a'b`, true},
+		{`path == "/a\\b"`, `/a\b`, true},
+		{`path == "/a\.b"`, `/a\.b`, true},
+		{`path =~ "/a\.b"`, `/a.b`, true},
+		{`path =~ "/a\.b"`, `/aXb`, false},
+		{`path =~ "/a\\.b"`, `/a.b`, true},
+		{`path =~ "/a\\.b"`, `/aXb`, false},
+		{`path =~ "/a\\\\.b"`, `/a\Xb`, true},
+	}
+	for _, tt := range tests {
+		expr, err := ParseCSEL(tt.expression)
+		if err != nil {
+			t.Fatalf("ParseCSEL(%q) returned %v", tt.expression, err)
+		}
+		if got := expr.Evaluate(map[string]string{"path": tt.value}); got != tt.matches {
+			t.Errorf("%q against %q returned %v, expected %v", tt.expression, tt.value, got, tt.matches)
+		}
+	}
+}
+
+func TestCSELEvaluateCoordinates(t *testing.T) {
+	expr, err := ParseCSEL(`coordinate.groupId =^ "com.acme" and coordinate.version != "1.0"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !expr.Evaluate(map[string]string{"coordinate.groupId": "com.acme.app", "coordinate.version": "2.0"}) {
+		t.Error("Expected coordinates to match")
+	}
+	if expr.Evaluate(map[string]string{"coordinate.groupId": "com.acme.app"}) {
+		t.Error("Expected a missing coordinate not to match")
+	}
+}
@@ -0,0 +1,439 @@
+package nexus
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// checksumAlgorithms lists the checksums Nexus reports for assets, strongest first.
+var checksumAlgorithms = []struct {
+	name string
+	new  func() hash.Hash
+}{
+	{"sha512", sha512.New},
+	{"sha256", sha256.New},
+	{"sha1", sha1.New},
+	{"md5", md5.New},
+}
+
+// checksumVerifier hashes content as it is written and compares the result to
+// the strongest checksum Nexus reported for an asset.
+type checksumVerifier struct {
+	hash.Hash
+	path      string
+	algorithm string
+	expected  string
+}
+
+// newChecksumVerifier returns a verifier for the asset, or nil if Nexus did not
+// report a checksum the client knows how to compute.
+func newChecksumVerifier(a *Asset) *checksumVerifier {
+	if a.Checksum == nil {
+		return nil
+	}
+	for _, algo := range checksumAlgorithms {
+		if expected, ok := (*a.Checksum)[algo.name]; ok && expected != "" {
+			v := &checksumVerifier{
+				Hash:      algo.new(),
+				algorithm: algo.name,
+				expected:  strings.ToLower(expected),
+			}
+			if a.Path != nil {
+				v.path = *a.Path
+			}
+			return v
+		}
+	}
+	return nil
+}
+
+// Verify returns a *ChecksumError if the content written so far does not match.
+func (v *checksumVerifier) Verify() error {
+	actual := hex.EncodeToString(v.Sum(nil))
+	if actual != v.expected {
+		return &ChecksumError{
+			Path:      v.path,
+			Algorithm: v.algorithm,
+			Expected:  v.expected,
+			Actual:    actual,
+		}
+	}
+	return nil
+}
+
+// checksumReader verifies the content read from the underlying body once it is exhausted.
+type checksumReader struct {
+	body     io.ReadCloser
+	verifier *checksumVerifier
+}
+
+func (r *checksumReader) Read(p []byte) (n int, err error) {
+	n, err = r.body.Read(p)
+	r.verifier.Write(p[:n])
+	if err == io.EOF {
+		if verr := r.verifier.Verify(); verr != nil {
+			err = verr
+		}
+	}
+	return
+}
+
+func (r *checksumReader) Close() error {
+	return r.body.Close()
+}
+
+// downloadEndpoint returns the path of the asset's download URL relative to the host.
+func (a *Asset) downloadEndpoint() (endpoint string, err error) {
+	if a.DownloadURL == nil {
+		err = errors.New("Asset does not have a download URL")
+		return
+	}
+	endpoint = strings.TrimPrefix(strings.Replace(*a.DownloadURL, a.client.host, "", 1), "/")
+	return
+}
+
+// openResponse starts the download and returns the unread response.
+func (a *Asset) openResponse(ctx context.Context, method string, header http.Header) (resp *http.Response, err error) {
+	endpoint, err := a.downloadEndpoint()
+	if err != nil {
+		return
+	}
+	req, err := a.client.NewRequestWithContext(ctx, method, endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err = a.client.DoStream(req, map[int]string{
+		403: "Insufficient permissions to download asset",
+		404: "Asset does not exist",
+	})
+	return
+}
+
+// Open starts downloading this asset and returns the body as a stream. When Nexus
+// reported a checksum for the asset, reading the stream to the end returns a
+// *ChecksumError instead of io.EOF if the content does not match. The caller
+// must close the returned reader.
+func (a *Asset) Open() (io.ReadCloser, error) {
+	return a.OpenWithContext(context.Background())
+}
+
+// OpenWithContext is the same as Open with the addition of a context.
+// Cancelling the context aborts the transfer.
+func (a *Asset) OpenWithContext(ctx context.Context) (body io.ReadCloser, err error) {
+	resp, err := a.openResponse(ctx, "GET", nil)
+	if err != nil {
+		return
+	}
+	body = resp.Body
+	if verifier := newChecksumVerifier(a); verifier != nil {
+		body = &checksumReader{body: body, verifier: verifier}
+	}
+	return
+}
+
+// DownloadTo streams this asset to the given writer without buffering it in memory
+// and returns the number of bytes written. The content is verified against the
+// checksums Nexus reported for the asset, and a *ChecksumError is returned on a mismatch.
+// Because the content is only verified once it has been written, callers should
+// discard the written data when an error is returned.
+//
+// Example
+//
+// Download an asset to disk
+//
+//     file, err := os.Create("test.tar.gz")
+//     if err != nil {
+//         log.Fatal(err)
+//     }
+//     defer file.Close()
+//     if _, err := asset.DownloadTo(context.Background(), file); err != nil {
+//         log.Fatal(err)
+//     }
+func (a *Asset) DownloadTo(ctx context.Context, w io.Writer) (written int64, err error) {
+	body, err := a.OpenWithContext(ctx)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+	written, err = io.Copy(w, body)
+	return
+}
+
+// minChunkSize is the smallest range requested by a parallel download.
+const minChunkSize = 4 << 20
+
+// DownloadFileInput is used to provide parameters to DownloadFile.
+// Path is required. When Resume is true and a file already exists at Path, only
+// the remaining bytes are requested from Nexus. When Concurrency is greater than 1
+// and the server supports range requests, the download is split into that many
+// ranges fetched in parallel.
+type DownloadFileInput struct {
+	Path        *string
+	Resume      *bool
+	Concurrency *int
+}
+
+// downloadInfo is what a HEAD request reports about an asset's content.
+type downloadInfo struct {
+	size   int64
+	ranges bool
+	etag   string
+}
+
+// byteRange is an inclusive range of bytes of an asset.
+type byteRange struct {
+	start int64
+	end   int64
+}
+
+func (r byteRange) length() int64 {
+	return r.end - r.start + 1
+}
+
+// DownloadFile downloads this asset to the file at the given path, resuming or
+// splitting the download into parallel ranges when requested. The finished file
+// is verified against the checksums Nexus reported for the asset, and it is
+// removed if they do not match.
+//
+// If a parallel download fails, the file is truncated to the bytes that were
+// received in order, so it can be resumed with a later call.
+//
+// Example
+//
+// Resume a large download using 4 connections
+//
+//     err := asset.DownloadFile(&nexus.DownloadFileInput{
+//         Path:        nexus.String("release.iso"),
+//         Resume:      nexus.Bool(true),
+//         Concurrency: nexus.Int(4),
+//     })
+func (a *Asset) DownloadFile(input *DownloadFileInput) (err error) {
+	return a.DownloadFileWithContext(context.Background(), input)
+}
+
+// DownloadFileWithContext is the same as DownloadFile with the addition of a context
+func (a *Asset) DownloadFileWithContext(ctx context.Context, input *DownloadFileInput) (err error) {
+	if input.Path == nil {
+		err = errors.New("Path is required for DownloadFile")
+		return
+	}
+	info, err := a.headDownload(ctx)
+	if err != nil {
+		return
+	}
+	file, err := os.OpenFile(*input.Path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	var offset int64
+	if input.Resume != nil && *input.Resume && info.ranges && info.size >= 0 {
+		stat, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		if stat.Size() <= info.size {
+			offset = stat.Size()
+		}
+	}
+	concurrency := 1
+	if input.Concurrency != nil {
+		concurrency = *input.Concurrency
+	}
+	if concurrency > 1 && info.ranges && info.size-offset > minChunkSize {
+		err = a.downloadRanges(ctx, file, info, offset, concurrency)
+		if err == nil {
+			err = a.verifyFile(file, info.size)
+		}
+	} else {
+		err = a.downloadSequential(ctx, file, info, offset)
+	}
+	if errors.Is(err, ErrChecksumMismatch) {
+		file.Close()
+		os.Remove(*input.Path)
+	}
+	return
+}
+
+// headDownload asks Nexus for the size of the asset and whether it accepts range requests.
+func (a *Asset) headDownload(ctx context.Context) (info *downloadInfo, err error) {
+	info = &downloadInfo{size: -1}
+	resp, err := a.openResponse(ctx, "HEAD", nil)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusMethodNotAllowed {
+			err = nil
+		}
+		return
+	}
+	resp.Body.Close()
+	info.size = resp.ContentLength
+	info.ranges = resp.Header.Get("Accept-Ranges") == "bytes"
+	info.etag = resp.Header.Get("ETag")
+	return
+}
+
+// downloadSequential writes the asset to the file from the given offset, hashing
+// the content as it is written.
+func (a *Asset) downloadSequential(ctx context.Context, file *os.File, info *downloadInfo, offset int64) (err error) {
+	if offset > 0 && offset == info.size {
+		return a.verifyFile(file, info.size)
+	}
+	header := http.Header{}
+	if offset > 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		if info.etag != "" {
+			header.Set("If-Range", info.etag)
+		}
+	}
+	resp, err := a.openResponse(ctx, "GET", header)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	// The server starts over when it ignores the range or the asset has changed
+	if resp.StatusCode != http.StatusPartialContent {
+		offset = 0
+	}
+	if err = file.Truncate(offset); err != nil {
+		return
+	}
+	if _, err = file.Seek(offset, io.SeekStart); err != nil {
+		return
+	}
+	var w io.Writer = file
+	verifier := newChecksumVerifier(a)
+	if verifier != nil {
+		if _, err = io.Copy(verifier, io.NewSectionReader(file, 0, offset)); err != nil {
+			return
+		}
+		w = io.MultiWriter(file, verifier)
+	}
+	if _, err = io.Copy(w, resp.Body); err != nil {
+		return
+	}
+	if verifier != nil {
+		err = verifier.Verify()
+	}
+	return
+}
+
+// downloadRanges fetches the asset from the given offset to the end in parallel
+// ranges, writing each at its position in the file.
+func (a *Asset) downloadRanges(ctx context.Context, file *os.File, info *downloadInfo, offset int64, concurrency int) (err error) {
+	if err = file.Truncate(offset); err != nil {
+		return
+	}
+	chunkSize := (info.size - offset + int64(concurrency) - 1) / int64(concurrency)
+	if chunkSize < minChunkSize {
+		chunkSize = minChunkSize
+	}
+	ranges := make([]byteRange, 0, concurrency)
+	for start := offset; start < info.size; start += chunkSize {
+		end := start + chunkSize - 1
+		if end >= info.size {
+			end = info.size - 1
+		}
+		ranges = append(ranges, byteRange{start: start, end: end})
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	written := make([]int64, len(ranges))
+	errs := make([]error, len(ranges))
+	var wg sync.WaitGroup
+	for idx := range ranges {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			errs[idx] = a.downloadRange(ctx, file, ranges[idx], info.etag, &written[idx])
+			if errs[idx] != nil {
+				cancel()
+			}
+		}(idx)
+	}
+	wg.Wait()
+	for _, rangeErr := range errs {
+		// Prefer the error that caused the other ranges to be cancelled
+		if rangeErr != nil && (err == nil || errors.Is(err, context.Canceled)) {
+			err = rangeErr
+		}
+	}
+	if err != nil {
+		// Keep only the bytes received in order so the download can be resumed
+		complete := offset
+		for idx, r := range ranges {
+			complete += written[idx]
+			if written[idx] < r.length() {
+				break
+			}
+		}
+		file.Truncate(complete)
+	}
+	return
+}
+
+// downloadRange fetches a single range of the asset into the file, counting the
+// bytes written into written.
+func (a *Asset) downloadRange(ctx context.Context, file *os.File, r byteRange, etag string, written *int64) (err error) {
+	header := http.Header{}
+	header.Set("Range", fmt.Sprintf("bytes=%d-%d", r.start, r.end))
+	if etag != "" {
+		header.Set("If-Range", etag)
+	}
+	resp, err := a.openResponse(ctx, "GET", header)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent {
+		err = fmt.Errorf("Expected a partial response for bytes %d-%d, got a status code of %v", r.start, r.end, resp.StatusCode)
+		return
+	}
+	w := &offsetWriter{file: file, offset: r.start, written: written}
+	_, err = io.Copy(w, io.LimitReader(resp.Body, r.length()))
+	if err == nil && *written != r.length() {
+		err = io.ErrUnexpectedEOF
+	}
+	return
+}
+
+// verifyFile hashes the first size bytes of the file and compares them to the asset's checksum.
+func (a *Asset) verifyFile(file *os.File, size int64) (err error) {
+	verifier := newChecksumVerifier(a)
+	if verifier == nil {
+		return
+	}
+	if _, err = io.Copy(verifier, io.NewSectionReader(file, 0, size)); err != nil {
+		return
+	}
+	return verifier.Verify()
+}
+
+// offsetWriter writes sequentially into a file starting at the given offset.
+type offsetWriter struct {
+	file    *os.File
+	offset  int64
+	written *int64
+}
+
+func (w *offsetWriter) Write(p []byte) (n int, err error) {
+	n, err = w.file.WriteAt(p, w.offset)
+	w.offset += int64(n)
+	*w.written += int64(n)
+	return
+}
@@ -0,0 +1,173 @@
+package nexus
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// rangedContent is large enough to be split into four ranges of minChunkSize
+var rangedContent = func() []byte {
+	content := make([]byte, 3*minChunkSize+minChunkSize/2)
+	rand.New(rand.NewSource(1)).Read(content)
+	return content
+}()
+
+// newRangedAsset returns an asset served with range support by the given server
+// handler. handle is called before each request is served and returns false when
+// it has written its own response.
+func newRangedAsset(t *testing.T, handle func(w http.ResponseWriter, r *http.Request) bool) (*Asset, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if handle != nil && !handle(w, r) {
+			return
+		}
+		w.Header().Set("ETag", `"{SHA1{asset}}"`)
+		http.ServeContent(w, r, "asset.bin", time.Time{}, bytes.NewReader(rangedContent))
+	}))
+	client, err := NewWithOptions(srv.URL, WithoutStatusCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(rangedContent)
+	asset := &Asset{
+		DownloadURL: String(srv.URL + "/repository/raw/asset.bin"),
+		Path:        String("asset.bin"),
+		Checksum:    &map[string]string{"sha256": hex.EncodeToString(sum[:])},
+		client:      client,
+	}
+	return asset, srv.Close
+}
+
+func tempDownloadPath(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "download")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(dir, "asset.bin"), func() { os.RemoveAll(dir) }
+}
+
+func TestDownloadFileRanges(t *testing.T) {
+	var mu sync.Mutex
+	ranges := make([]string, 0)
+	asset, closeServer := newRangedAsset(t, func(w http.ResponseWriter, r *http.Request) bool {
+		if r.Method == "GET" {
+			mu.Lock()
+			ranges = append(ranges, r.Header.Get("Range"))
+			mu.Unlock()
+		}
+		return true
+	})
+	defer closeServer()
+	path, cleanup := tempDownloadPath(t)
+	defer cleanup()
+	if err := asset.DownloadFile(&DownloadFileInput{Path: String(path), Concurrency: Int(4)}); err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, rangedContent) {
+		t.Errorf("Downloaded %d bytes that do not match the asset", len(content))
+	}
+	if len(ranges) != 4 {
+		t.Errorf("Expected 4 ranged requests, got %v", ranges)
+	}
+	for _, r := range ranges {
+		if !strings.HasPrefix(r, "bytes=") {
+			t.Errorf("Expected a range request, got %q", r)
+		}
+	}
+}
+
+func TestDownloadFileRangesTruncatesToContiguousPrefix(t *testing.T) {
+	var fail sync.Once
+	laterRanges := make(chan struct{}, 2)
+	asset, closeServer := newRangedAsset(t, func(w http.ResponseWriter, r *http.Request) bool {
+		switch r.Header.Get("Range") {
+		case "bytes=" + itoa(minChunkSize) + "-" + itoa(2*minChunkSize-1):
+			failed := false
+			fail.Do(func() {
+				failed = true
+				// Fail once the ranges after this one have been written
+				for i := 0; i < 2; i++ {
+					select {
+					case <-laterRanges:
+					case <-time.After(5 * time.Second):
+					}
+				}
+				w.WriteHeader(http.StatusInternalServerError)
+			})
+			return !failed
+		case "bytes=" + itoa(2*minChunkSize) + "-" + itoa(3*minChunkSize-1),
+			"bytes=" + itoa(3*minChunkSize) + "-" + itoa(int64(len(rangedContent))-1):
+			defer func() { laterRanges <- struct{}{} }()
+			w.Header().Set("ETag", `"{SHA1{asset}}"`)
+			http.ServeContent(w, r, "asset.bin", time.Time{}, bytes.NewReader(rangedContent))
+			return false
+		}
+		return true
+	})
+	defer closeServer()
+	path, cleanup := tempDownloadPath(t)
+	defer cleanup()
+	if err := asset.DownloadFile(&DownloadFileInput{Path: String(path), Concurrency: Int(4)}); err == nil {
+		t.Fatal("Expected the failed range to fail the download")
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Nothing was received for the second range, so only the first can be kept
+	if len(content) > minChunkSize || !bytes.Equal(content, rangedContent[:len(content)]) {
+		t.Fatalf("Expected the file to be truncated to a prefix of the first range, got %d bytes", len(content))
+	}
+	if err := asset.DownloadFile(&DownloadFileInput{Path: String(path), Resume: Bool(true)}); err != nil {
+		t.Fatal(err)
+	}
+	if content, err = ioutil.ReadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, rangedContent) {
+		t.Errorf("Resumed %d bytes that do not match the asset", len(content))
+	}
+}
+
+func TestDownloadRangeRequiresPartialContent(t *testing.T) {
+	asset, closeServer := newRangedAsset(t, func(w http.ResponseWriter, r *http.Request) bool {
+		// Ignore the range and send the whole asset
+		r.Header.Del("Range")
+		return true
+	})
+	defer closeServer()
+	path, cleanup := tempDownloadPath(t)
+	defer cleanup()
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var written int64
+	if err := asset.downloadRange(context.Background(), file, byteRange{start: 10, end: 19}, "", &written); err == nil {
+		t.Error("Expected an error when the range is ignored")
+	}
+	if written != 0 {
+		t.Errorf("Expected nothing to be written, got %d bytes", written)
+	}
+}
+
+func itoa(i int64) string {
+	return strconv.FormatInt(i, 10)
+}
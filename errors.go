@@ -0,0 +1,165 @@
+package nexus
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// Sentinel errors that can be compared against any error returned by the client
+// using errors.Is.
+//
+// Example
+//
+// Create a script only when it does not already exist
+//
+//     _, err := client.GetScript("my-script")
+//     if errors.Is(err, nexus.ErrNotFound) {
+//         // create it
+//     } else if err != nil {
+//         log.Fatal(err)
+//     }
+var (
+	// ErrUnauthorized is matched when Nexus rejects the provided credentials.
+	ErrUnauthorized = errors.New("Unauthorized")
+	// ErrForbidden is matched when the user lacks the permissions for an operation.
+	ErrForbidden = errors.New("Forbidden")
+	// ErrNotFound is matched when the requested object does not exist.
+	ErrNotFound = errors.New("Not found")
+	// ErrConflict is matched when the object being created already exists.
+	ErrConflict = errors.New("Conflict")
+	// ErrChecksumMismatch is matched when downloaded content does not match the
+	// checksum reported by Nexus.
+	ErrChecksumMismatch = errors.New("Checksum mismatch")
+	// ErrMultipleAssets is matched when a search expected to resolve a single
+	// asset matched more than one.
+	ErrMultipleAssets = errors.New("Multiple assets matched")
+)
+
+// maxErrorBodySize limits how much of a failed response body is kept on an APIError.
+const maxErrorBodySize = 64 * 1024
+
+// requestIDHeaders are checked in order for a request ID to attach to an APIError.
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "X-Amzn-Trace-Id"}
+
+// APIError is returned when Nexus responds with a non-2xx status code. It can
+// be retrieved from any error returned by the client with errors.As, and matches
+// the sentinel errors above with errors.Is based on the status code.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Method is the HTTP method of the request.
+	Method string
+	// URL is the full URL of the request.
+	URL string
+	// Body is the response body returned by Nexus, if any.
+	Body string
+	// RequestID is the request ID reported in the response headers, if any.
+	RequestID string
+	// Message is a human-readable description of the failure provided by the operation.
+	Message string
+
+	kind error
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if e.Body != "" {
+		return fmt.Sprintf("%s %s returned a status code of %v: %s", e.Method, e.URL, e.StatusCode, e.Body)
+	}
+	return fmt.Sprintf("%s %s returned a status code of %v", e.Method, e.URL, e.StatusCode)
+}
+
+// Is reports whether the error matches one of the sentinel errors.
+func (e *APIError) Is(target error) bool {
+	if e.kind != nil && target == e.kind {
+		return true
+	}
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	}
+	return false
+}
+
+// newAPIError builds an APIError from a failed response. The message is taken from
+// the statusMap when present, or from the response body when resToErr is true.
+func newAPIError(req *http.Request, resp *http.Response, statusMap map[int]string, resToErr bool) *APIError {
+	content, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		Body:       string(content),
+	}
+	for _, header := range requestIDHeaders {
+		if id := resp.Header.Get(header); id != "" {
+			apiErr.RequestID = id
+			break
+		}
+	}
+	if resToErr {
+		apiErr.Message = apiErr.Body
+	} else if statusMap != nil {
+		apiErr.Message = statusMap[resp.StatusCode]
+	}
+	return apiErr
+}
+
+// clientError is used for failures detected by the client itself, such as a
+// script reporting that an object does not exist, so they still match the
+// sentinel errors.
+type clientError struct {
+	message string
+	kind    error
+}
+
+func (e *clientError) Error() string {
+	return e.message
+}
+
+func (e *clientError) Unwrap() error {
+	return e.kind
+}
+
+func notFoundErrorf(format string, args ...interface{}) error {
+	return &clientError{message: fmt.Sprintf(format, args...), kind: ErrNotFound}
+}
+
+func conflictErrorf(format string, args ...interface{}) error {
+	return &clientError{message: fmt.Sprintf(format, args...), kind: ErrConflict}
+}
+
+// ChecksumError is returned when downloaded content does not match the checksum
+// Nexus reported for the asset. It matches ErrChecksumMismatch with errors.Is.
+type ChecksumError struct {
+	// Path is the path of the asset in its repository.
+	Path string
+	// Algorithm is the checksum algorithm that was compared, e.g. sha256.
+	Algorithm string
+	// Expected is the checksum reported by Nexus.
+	Expected string
+	// Actual is the checksum of the downloaded content.
+	Actual string
+}
+
+// Error implements the error interface
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("%s checksum of %s does not match: expected %s, got %s", e.Algorithm, e.Path, e.Expected, e.Actual)
+}
+
+// Unwrap returns ErrChecksumMismatch
+func (e *ChecksumError) Unwrap() error {
+	return ErrChecksumMismatch
+}
@@ -1,6 +1,7 @@
 package nexus
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -30,8 +31,13 @@ type AssetField struct {
 
 // GetFormat retrieves a single repository type's format
 func (n *Nexus) GetFormat(format string) (res *Format, err error) {
+	return n.GetFormatWithContext(context.Background(), format)
+}
+
+// GetFormatWithContext is the same as GetFormat with the addition of a context
+func (n *Nexus) GetFormatWithContext(ctx context.Context, format string) (res *Format, err error) {
 	endpoint := fmt.Sprintf("service/rest/v1/formats/%s/upload-specs", format)
-	req, err := n.NewRequest("GET", endpoint, nil, nil, "")
+	req, err := n.NewRequestWithContext(ctx, "GET", endpoint, nil, nil, "")
 	if err != nil {
 		return
 	}
@@ -47,8 +53,13 @@ func (n *Nexus) GetFormat(format string) (res *Format, err error) {
 
 // ListFormats returns a list of the available repository formats
 func (n *Nexus) ListFormats() (res []*Format, err error) {
+	return n.ListFormatsWithContext(context.Background())
+}
+
+// ListFormatsWithContext is the same as ListFormats with the addition of a context
+func (n *Nexus) ListFormatsWithContext(ctx context.Context) (res []*Format, err error) {
 	res = make([]*Format, 0)
-	req, err := n.NewRequest("GET", "service/rest/v1/formats/upload-specs", nil, nil, "")
+	req, err := n.NewRequestWithContext(ctx, "GET", "service/rest/v1/formats/upload-specs", nil, nil, "")
 	if err != nil {
 		return
 	}
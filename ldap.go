@@ -0,0 +1,332 @@
+package nexus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// LDAPProtocolLDAP and LDAPProtocolLDAPS are used when specifying LDAPServer.Protocol
+var (
+	LDAPProtocolLDAP  = String("ldap")
+	LDAPProtocolLDAPS = String("ldaps")
+)
+
+// LDAPAuthSchemeNone and friends are used when specifying LDAPServer.AuthScheme
+var (
+	LDAPAuthSchemeNone      = String("NONE")
+	LDAPAuthSchemeSimple    = String("SIMPLE")
+	LDAPAuthSchemeDigestMD5 = String("DIGEST_MD5")
+	LDAPAuthSchemeCramMD5   = String("CRAM_MD5")
+)
+
+// LDAPGroupTypeStatic and LDAPGroupTypeDynamic are used when specifying LDAPServer.GroupType.
+// Static groups list their members, while dynamic groups are listed in an attribute
+// of each user, such as memberOf.
+var (
+	LDAPGroupTypeStatic  = String("static")
+	LDAPGroupTypeDynamic = String("dynamic")
+)
+
+// LDAPServer represents the configuration of an LDAP server used by the LDAP realm.
+// AuthPassword is only used when creating, updating or testing a server and is
+// never returned, so it must be provided again on every update when the server
+// uses an AuthScheme other than none. ID and Order are assigned by Nexus.
+type LDAPServer struct {
+	ID    *string `json:"id,omitempty"`
+	Name  *string `json:"name"`
+	Order *int    `json:"order,omitempty"`
+
+	// Connection
+	Protocol                    *string `json:"protocol"`
+	UseTrustStore               *bool   `json:"useTrustStore,omitempty"`
+	Host                        *string `json:"host"`
+	Port                        *int    `json:"port"`
+	SearchBase                  *string `json:"searchBase"`
+	AuthScheme                  *string `json:"authScheme"`
+	AuthRealm                   *string `json:"authRealm,omitempty"`
+	AuthUsername                *string `json:"authUsername,omitempty"`
+	AuthPassword                *string `json:"authPassword,omitempty"`
+	ConnectionTimeoutSeconds    *int    `json:"connectionTimeoutSeconds"`
+	ConnectionRetryDelaySeconds *int    `json:"connectionRetryDelaySeconds"`
+	MaxIncidentsCount           *int    `json:"maxIncidentsCount"`
+
+	// User mapping
+	UserBaseDN                *string `json:"userBaseDn,omitempty"`
+	UserSubtree               *bool   `json:"userSubtree,omitempty"`
+	UserObjectClass           *string `json:"userObjectClass,omitempty"`
+	UserLDAPFilter            *string `json:"userLdapFilter,omitempty"`
+	UserIDAttribute           *string `json:"userIdAttribute,omitempty"`
+	UserRealNameAttribute     *string `json:"userRealNameAttribute,omitempty"`
+	UserEmailAddressAttribute *string `json:"userEmailAddressAttribute,omitempty"`
+	UserPasswordAttribute     *string `json:"userPasswordAttribute,omitempty"`
+
+	// Group mapping
+	LDAPGroupsAsRoles     *bool   `json:"ldapGroupsAsRoles,omitempty"`
+	GroupType             *string `json:"groupType,omitempty"`
+	GroupBaseDN           *string `json:"groupBaseDn,omitempty"`
+	GroupSubtree          *bool   `json:"groupSubtree,omitempty"`
+	GroupObjectClass      *string `json:"groupObjectClass,omitempty"`
+	GroupIDAttribute      *string `json:"groupIdAttribute,omitempty"`
+	GroupMemberAttribute  *string `json:"groupMemberAttribute,omitempty"`
+	GroupMemberFormat     *string `json:"groupMemberFormat,omitempty"`
+	UserMemberOfAttribute *string `json:"userMemberOfAttribute,omitempty"`
+}
+
+var testLDAPConnectionScriptName = String("nexus3-go-test-ldap-connection")
+var testLDAPConnectionScript = String(`
+import groovy.json.JsonSlurper
+import javax.naming.Context
+import javax.naming.ldap.InitialLdapContext
+
+parsed_args = new JsonSlurper().parseText(args)
+def env = new Hashtable()
+env.put(Context.INITIAL_CONTEXT_FACTORY, "com.sun.jndi.ldap.LdapCtxFactory")
+env.put(Context.PROVIDER_URL, "${parsed_args.protocol}://${parsed_args.host}:${parsed_args.port}".toString())
+env.put("com.sun.jndi.ldap.connect.timeout", String.valueOf((parsed_args.connectionTimeoutSeconds ?: 30) * 1000))
+switch (parsed_args.authScheme) {
+	case "SIMPLE":
+		env.put(Context.SECURITY_AUTHENTICATION, "simple")
+		break
+	case "DIGEST_MD5":
+		env.put(Context.SECURITY_AUTHENTICATION, "DIGEST-MD5")
+		break
+	case "CRAM_MD5":
+		env.put(Context.SECURITY_AUTHENTICATION, "CRAM-MD5")
+		break
+	default:
+		env.put(Context.SECURITY_AUTHENTICATION, "none")
+}
+if (parsed_args.authScheme && parsed_args.authScheme != "NONE") {
+	env.put(Context.SECURITY_PRINCIPAL, parsed_args.authUsername ?: "")
+	env.put(Context.SECURITY_CREDENTIALS, parsed_args.authPassword ?: "")
+	if (parsed_args.authRealm) {
+		env.put("java.naming.security.sasl.realm", parsed_args.authRealm)
+	}
+}
+try {
+	def ctx = new InitialLdapContext(env, null)
+	try {
+		ctx.getAttributes(parsed_args.searchBase ?: "")
+	} finally {
+		ctx.close()
+	}
+} catch (Exception e) {
+	return "error: " + e.toString()
+}
+return "ok"
+`)
+
+// ListLDAPServers returns the configured LDAP servers in the order they are consulted
+func (n *Nexus) ListLDAPServers() (res []*LDAPServer, err error) {
+	return n.ListLDAPServersWithContext(context.Background())
+}
+
+// ListLDAPServersWithContext is the same as ListLDAPServers with the addition of a context
+func (n *Nexus) ListLDAPServersWithContext(ctx context.Context) (res []*LDAPServer, err error) {
+	res = make([]*LDAPServer, 0)
+	req, err := n.NewRequestWithContext(ctx, "GET", "service/rest/v1/security/ldap", nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: "Insufficient permissions to list LDAP servers",
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// GetLDAPServer retrieves an LDAP server by name
+func (n *Nexus) GetLDAPServer(name string) (res *LDAPServer, err error) {
+	return n.GetLDAPServerWithContext(context.Background(), name)
+}
+
+// GetLDAPServerWithContext is the same as GetLDAPServer with the addition of a context
+func (n *Nexus) GetLDAPServerWithContext(ctx context.Context, name string) (res *LDAPServer, err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/security/ldap/%s", name)
+	req, err := n.NewRequestWithContext(ctx, "GET", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to get LDAP server %s", name),
+		404: fmt.Sprintf("LDAP server %s does not exist", name),
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// CreateLDAPServer adds a new LDAP server after the existing ones. Name, Protocol,
+// Host, Port, SearchBase, AuthScheme and the connection limits are required.
+//
+// Example
+//
+// Add an Active Directory server that maps the groups of users to roles
+//
+//     err := client.CreateLDAPServer(&nexus.LDAPServer{
+//         Name:                        nexus.String("corp-ad"),
+//         Protocol:                    nexus.LDAPProtocolLDAPS,
+//         Host:                        nexus.String("ad.example.com"),
+//         Port:                        nexus.Int(636),
+//         SearchBase:                  nexus.String("dc=example,dc=com"),
+//         AuthScheme:                  nexus.LDAPAuthSchemeSimple,
+//         AuthUsername:                nexus.String("cn=nexus,ou=services,dc=example,dc=com"),
+//         AuthPassword:                nexus.String(os.Getenv("LDAP_PASSWORD")),
+//         ConnectionTimeoutSeconds:    nexus.Int(30),
+//         ConnectionRetryDelaySeconds: nexus.Int(300),
+//         MaxIncidentsCount:           nexus.Int(3),
+//         UserBaseDN:                  nexus.String("ou=people"),
+//         UserSubtree:                 nexus.Bool(true),
+//         UserObjectClass:             nexus.String("user"),
+//         UserIDAttribute:             nexus.String("sAMAccountName"),
+//         UserRealNameAttribute:       nexus.String("cn"),
+//         UserEmailAddressAttribute:   nexus.String("mail"),
+//         LDAPGroupsAsRoles:           nexus.Bool(true),
+//         GroupType:                   nexus.LDAPGroupTypeDynamic,
+//         UserMemberOfAttribute:       nexus.String("memberOf"),
+//     })
+func (n *Nexus) CreateLDAPServer(server *LDAPServer) (err error) {
+	return n.CreateLDAPServerWithContext(context.Background(), server)
+}
+
+// CreateLDAPServerWithContext is the same as CreateLDAPServer with the addition of a context
+func (n *Nexus) CreateLDAPServerWithContext(ctx context.Context, server *LDAPServer) (err error) {
+	if server.Name == nil {
+		err = errors.New("Name is required for CreateLDAPServer")
+		return
+	}
+	input := *server
+	input.ID = nil
+	input.Order = nil
+	payload, err := json.Marshal(&input)
+	if err != nil {
+		return
+	}
+	req, err := n.NewRequestWithContext(ctx, "POST", "service/rest/v1/security/ldap", nil, payload, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to create LDAP server %s", *server.Name),
+	}, false)
+	return
+}
+
+// UpdateLDAPServer replaces the configuration of an existing LDAP server. The ID
+// is looked up when it is not set.
+func (n *Nexus) UpdateLDAPServer(server *LDAPServer) (err error) {
+	return n.UpdateLDAPServerWithContext(context.Background(), server)
+}
+
+// UpdateLDAPServerWithContext is the same as UpdateLDAPServer with the addition of a context
+func (n *Nexus) UpdateLDAPServerWithContext(ctx context.Context, server *LDAPServer) (err error) {
+	if server.Name == nil {
+		err = errors.New("Name is required for UpdateLDAPServer")
+		return
+	}
+	input := *server
+	if input.ID == nil {
+		var current *LDAPServer
+		current, err = n.GetLDAPServerWithContext(ctx, *server.Name)
+		if err != nil {
+			return
+		}
+		input.ID = current.ID
+	}
+	input.Order = nil
+	payload, err := json.Marshal(&input)
+	if err != nil {
+		return
+	}
+	endpoint := fmt.Sprintf("service/rest/v1/security/ldap/%s", *server.Name)
+	req, err := n.NewRequestWithContext(ctx, "PUT", endpoint, nil, payload, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to update LDAP server %s", *server.Name),
+		404: fmt.Sprintf("LDAP server %s does not exist", *server.Name),
+	}, false)
+	return
+}
+
+// DeleteLDAPServer removes the LDAP server with the given name
+func (n *Nexus) DeleteLDAPServer(name string) (err error) {
+	return n.DeleteLDAPServerWithContext(context.Background(), name)
+}
+
+// DeleteLDAPServerWithContext is the same as DeleteLDAPServer with the addition of a context
+func (n *Nexus) DeleteLDAPServerWithContext(ctx context.Context, name string) (err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/security/ldap/%s", name)
+	req, err := n.NewRequestWithContext(ctx, "DELETE", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to delete LDAP server %s", name),
+		404: fmt.Sprintf("LDAP server %s does not exist", name),
+	}, false)
+	return
+}
+
+// SetLDAPServerOrder changes the order the LDAP servers are consulted in. Every
+// configured server must be named.
+func (n *Nexus) SetLDAPServerOrder(names []string) (err error) {
+	return n.SetLDAPServerOrderWithContext(context.Background(), names)
+}
+
+// SetLDAPServerOrderWithContext is the same as SetLDAPServerOrder with the addition of a context
+func (n *Nexus) SetLDAPServerOrderWithContext(ctx context.Context, names []string) (err error) {
+	payload, err := json.Marshal(names)
+	if err != nil {
+		return
+	}
+	req, err := n.NewRequestWithContext(ctx, "POST", "service/rest/v1/security/ldap/change-order", nil, payload, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		400: "The LDAP server order must name every configured server",
+		403: "Insufficient permissions to change the LDAP server order",
+	}, false)
+	return
+}
+
+// TestLDAPConnection checks that Nexus can connect and bind to the given LDAP server
+// and read its search base. The server does not need to exist yet, which allows a
+// configuration to be checked before it is created. Nexus has no REST endpoint for
+// this, so a groovy script is installed to connect from the Nexus host. The Nexus
+// truststore is not consulted, so UseTrustStore is ignored.
+func (n *Nexus) TestLDAPConnection(server *LDAPServer) (err error) {
+	return n.TestLDAPConnectionWithContext(context.Background(), server)
+}
+
+// TestLDAPConnectionWithContext is the same as TestLDAPConnection with the addition of a context
+func (n *Nexus) TestLDAPConnectionWithContext(ctx context.Context, server *LDAPServer) (err error) {
+	if server.Host == nil || server.Port == nil || server.Protocol == nil {
+		err = errors.New("Protocol, Host and Port are required for TestLDAPConnection")
+		return
+	}
+	script := &Script{
+		Name:    testLDAPConnectionScriptName,
+		Type:    ScriptTypeGroovy,
+		Content: testLDAPConnectionScript,
+		client:  n,
+	}
+	res, err := script.ensureAndExecute(ctx, server)
+	if err != nil {
+		return
+	}
+	if *res.Result != "ok" {
+		err = fmt.Errorf("Connection to LDAP server %s:%d failed: %s", *server.Host, *server.Port, strings.TrimPrefix(*res.Result, "error: "))
+	}
+	return
+}
@@ -0,0 +1,313 @@
+package nexus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// PrivilegeTypeApplication and friends are the values of Privilege.Type
+var (
+	PrivilegeTypeApplication               = String("application")
+	PrivilegeTypeWildcard                  = String("wildcard")
+	PrivilegeTypeRepositoryView            = String("repository-view")
+	PrivilegeTypeRepositoryAdmin           = String("repository-admin")
+	PrivilegeTypeRepositoryContentSelector = String("repository-content-selector")
+	PrivilegeTypeScript                    = String("script")
+)
+
+// PrivilegeActionRead and friends are used when specifying the Actions of a privilege.
+// Application privileges use the CRUD actions, while repository and script privileges
+// use BROWSE, READ, EDIT, ADD, DELETE and RUN as applicable.
+const (
+	PrivilegeActionCreate = "CREATE"
+	PrivilegeActionRead   = "READ"
+	PrivilegeActionUpdate = "UPDATE"
+	PrivilegeActionDelete = "DELETE"
+	PrivilegeActionBrowse = "BROWSE"
+	PrivilegeActionEdit   = "EDIT"
+	PrivilegeActionAdd    = "ADD"
+	PrivilegeActionRun    = "RUN"
+	PrivilegeActionAll    = "ALL"
+)
+
+// Privilege represents a privilege of any type. Only the fields that apply to
+// its Type are populated. Format and Repository may be "*" to match every format
+// or repository.
+type Privilege struct {
+	Type            *string  `json:"type"`
+	Name            *string  `json:"name"`
+	Description     *string  `json:"description,omitempty"`
+	ReadOnly        *bool    `json:"readOnly,omitempty"`
+	Actions         []string `json:"actions,omitempty"`
+	Domain          *string  `json:"domain,omitempty"`
+	Pattern         *string  `json:"pattern,omitempty"`
+	Format          *string  `json:"format,omitempty"`
+	Repository      *string  `json:"repository,omitempty"`
+	ContentSelector *string  `json:"contentSelector,omitempty"`
+	ScriptName      *string  `json:"scriptName,omitempty"`
+}
+
+// PrivilegeInput is implemented by the inputs for each type of privilege that
+// can be passed to CreatePrivilege and UpdatePrivilege.
+type PrivilegeInput interface {
+	privilegeType() string
+	privilegeName() *string
+}
+
+// ApplicationPrivilegeInput creates a privilege for a domain of the Nexus
+// application, such as "users" or "blobstores".
+type ApplicationPrivilegeInput struct {
+	Name        *string  `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	Domain      *string  `json:"domain"`
+	Actions     []string `json:"actions"`
+}
+
+// WildcardPrivilegeInput creates a privilege from a raw permission pattern,
+// such as "nexus:repository-view:maven2:*:read".
+type WildcardPrivilegeInput struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description,omitempty"`
+	Pattern     *string `json:"pattern"`
+}
+
+// RepositoryViewPrivilegeInput creates a privilege for the content of repositories
+type RepositoryViewPrivilegeInput struct {
+	Name        *string  `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	Format      *string  `json:"format"`
+	Repository  *string  `json:"repository"`
+	Actions     []string `json:"actions"`
+}
+
+// RepositoryAdminPrivilegeInput creates a privilege for the configuration of repositories
+type RepositoryAdminPrivilegeInput struct {
+	Name        *string  `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	Format      *string  `json:"format"`
+	Repository  *string  `json:"repository"`
+	Actions     []string `json:"actions"`
+}
+
+// RepositoryContentSelectorPrivilegeInput creates a privilege for the content
+// of repositories that is matched by a content selector.
+type RepositoryContentSelectorPrivilegeInput struct {
+	Name            *string  `json:"name"`
+	Description     *string  `json:"description,omitempty"`
+	Format          *string  `json:"format,omitempty"`
+	Repository      *string  `json:"repository"`
+	ContentSelector *string  `json:"contentSelector"`
+	Actions         []string `json:"actions"`
+}
+
+// ScriptPrivilegeInput creates a privilege for a script, or for every script
+// when ScriptName is "*".
+type ScriptPrivilegeInput struct {
+	Name        *string  `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	ScriptName  *string  `json:"scriptName"`
+	Actions     []string `json:"actions"`
+}
+
+func (p *ApplicationPrivilegeInput) privilegeType() string {
+	return *PrivilegeTypeApplication
+}
+
+func (p *ApplicationPrivilegeInput) privilegeName() *string {
+	return p.Name
+}
+
+func (p *WildcardPrivilegeInput) privilegeType() string {
+	return *PrivilegeTypeWildcard
+}
+
+func (p *WildcardPrivilegeInput) privilegeName() *string {
+	return p.Name
+}
+
+func (p *RepositoryViewPrivilegeInput) privilegeType() string {
+	return *PrivilegeTypeRepositoryView
+}
+
+func (p *RepositoryViewPrivilegeInput) privilegeName() *string {
+	return p.Name
+}
+
+func (p *RepositoryAdminPrivilegeInput) privilegeType() string {
+	return *PrivilegeTypeRepositoryAdmin
+}
+
+func (p *RepositoryAdminPrivilegeInput) privilegeName() *string {
+	return p.Name
+}
+
+func (p *RepositoryContentSelectorPrivilegeInput) privilegeType() string {
+	return *PrivilegeTypeRepositoryContentSelector
+}
+
+func (p *RepositoryContentSelectorPrivilegeInput) privilegeName() *string {
+	return p.Name
+}
+
+func (p *ScriptPrivilegeInput) privilegeType() string {
+	return *PrivilegeTypeScript
+}
+
+func (p *ScriptPrivilegeInput) privilegeName() *string {
+	return p.Name
+}
+
+// privilegeInput returns the input that recreates this privilege
+func (p *Privilege) privilegeInput() (input PrivilegeInput, err error) {
+	switch stringValue(p.Type) {
+	case *PrivilegeTypeApplication:
+		input = &ApplicationPrivilegeInput{Name: p.Name, Description: p.Description, Domain: p.Domain, Actions: p.Actions}
+	case *PrivilegeTypeWildcard:
+		input = &WildcardPrivilegeInput{Name: p.Name, Description: p.Description, Pattern: p.Pattern}
+	case *PrivilegeTypeRepositoryView:
+		input = &RepositoryViewPrivilegeInput{Name: p.Name, Description: p.Description, Format: p.Format, Repository: p.Repository, Actions: p.Actions}
+	case *PrivilegeTypeRepositoryAdmin:
+		input = &RepositoryAdminPrivilegeInput{Name: p.Name, Description: p.Description, Format: p.Format, Repository: p.Repository, Actions: p.Actions}
+	case *PrivilegeTypeRepositoryContentSelector:
+		input = &RepositoryContentSelectorPrivilegeInput{Name: p.Name, Description: p.Description, Format: p.Format, Repository: p.Repository, ContentSelector: p.ContentSelector, Actions: p.Actions}
+	case *PrivilegeTypeScript:
+		input = &ScriptPrivilegeInput{Name: p.Name, Description: p.Description, ScriptName: p.ScriptName, Actions: p.Actions}
+	default:
+		err = fmt.Errorf("Privilege %s has unsupported type %s", stringValue(p.Name), stringValue(p.Type))
+	}
+	return
+}
+
+// ListPrivileges returns the privileges known to Nexus, including the ones
+// Nexus creates for each repository.
+func (n *Nexus) ListPrivileges() (res []*Privilege, err error) {
+	return n.ListPrivilegesWithContext(context.Background())
+}
+
+// ListPrivilegesWithContext is the same as ListPrivileges with the addition of a context
+func (n *Nexus) ListPrivilegesWithContext(ctx context.Context) (res []*Privilege, err error) {
+	res = make([]*Privilege, 0)
+	req, err := n.NewRequestWithContext(ctx, "GET", "service/rest/v1/security/privileges", nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: "Insufficient permissions to list privileges",
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// GetPrivilege retrieves a privilege by name
+func (n *Nexus) GetPrivilege(name string) (res *Privilege, err error) {
+	return n.GetPrivilegeWithContext(context.Background(), name)
+}
+
+// GetPrivilegeWithContext is the same as GetPrivilege with the addition of a context
+func (n *Nexus) GetPrivilegeWithContext(ctx context.Context, name string) (res *Privilege, err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/security/privileges/%s", name)
+	req, err := n.NewRequestWithContext(ctx, "GET", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to get privilege %s", name),
+		404: fmt.Sprintf("Privilege %s does not exist", name),
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// CreatePrivilege creates a new privilege of the type of the given input
+//
+// Example
+//
+// Allow a CI role to deploy to the maven releases repository
+//
+//     err := client.CreatePrivilege(&nexus.RepositoryViewPrivilegeInput{
+//         Name:       nexus.String("ci-maven-releases-deploy"),
+//         Format:     nexus.RepositoryFormatMaven,
+//         Repository: nexus.String("maven-releases"),
+//         Actions: []string{
+//             nexus.PrivilegeActionBrowse,
+//             nexus.PrivilegeActionRead,
+//             nexus.PrivilegeActionAdd,
+//             nexus.PrivilegeActionEdit,
+//         },
+//     })
+func (n *Nexus) CreatePrivilege(input PrivilegeInput) (err error) {
+	return n.CreatePrivilegeWithContext(context.Background(), input)
+}
+
+// CreatePrivilegeWithContext is the same as CreatePrivilege with the addition of a context
+func (n *Nexus) CreatePrivilegeWithContext(ctx context.Context, input PrivilegeInput) (err error) {
+	return n.writePrivilege(ctx, true, input)
+}
+
+// UpdatePrivilege replaces an existing privilege of the type of the given input
+func (n *Nexus) UpdatePrivilege(input PrivilegeInput) (err error) {
+	return n.UpdatePrivilegeWithContext(context.Background(), input)
+}
+
+// UpdatePrivilegeWithContext is the same as UpdatePrivilege with the addition of a context
+func (n *Nexus) UpdatePrivilegeWithContext(ctx context.Context, input PrivilegeInput) (err error) {
+	return n.writePrivilege(ctx, false, input)
+}
+
+func (n *Nexus) writePrivilege(ctx context.Context, create bool, input PrivilegeInput) (err error) {
+	name := input.privilegeName()
+	if name == nil {
+		err = errors.New("Name is required for a privilege")
+		return
+	}
+	body, err := json.Marshal(input)
+	if err != nil {
+		return
+	}
+	method := "PUT"
+	endpoint := fmt.Sprintf("service/rest/v1/security/privileges/%s/%s", input.privilegeType(), *name)
+	statusMap := map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to update privilege %s", *name),
+		404: fmt.Sprintf("Privilege %s does not exist", *name),
+	}
+	if create {
+		method = "POST"
+		endpoint = fmt.Sprintf("service/rest/v1/security/privileges/%s", input.privilegeType())
+		statusMap = map[int]string{
+			403: fmt.Sprintf("Insufficient permissions to create privilege %s", *name),
+		}
+	}
+	req, err := n.NewRequestWithContext(ctx, method, endpoint, nil, body, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, statusMap, false)
+	return
+}
+
+// DeletePrivilege removes the privilege with the given name
+func (n *Nexus) DeletePrivilege(name string) (err error) {
+	return n.DeletePrivilegeWithContext(context.Background(), name)
+}
+
+// DeletePrivilegeWithContext is the same as DeletePrivilege with the addition of a context
+func (n *Nexus) DeletePrivilegeWithContext(ctx context.Context, name string) (err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/security/privileges/%s", name)
+	req, err := n.NewRequestWithContext(ctx, "DELETE", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to delete privilege %s", name),
+		404: fmt.Sprintf("Privilege %s does not exist", name),
+	}, false)
+	return
+}
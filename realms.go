@@ -0,0 +1,107 @@
+package nexus
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// RealmLocalAuthenticating and friends are the IDs of the realms that ship with
+// Nexus, for use with SetActiveRealms. Other realms may be available depending on
+// the version and plugins installed, see ListAvailableRealms.
+const (
+	RealmLocalAuthenticating = "NexusAuthenticatingRealm"
+	RealmLocalAuthorizing    = "NexusAuthorizingRealm"
+	RealmLDAP                = "LdapRealm"
+	RealmDockerToken         = "DockerToken"
+	RealmNpmToken            = "NpmToken"
+	RealmNuGetAPIKey         = "NuGetApiKey"
+	RealmConanToken          = "org.sonatype.repository.conan.internal.security.token.ConanTokenRealm"
+	RealmRutAuth             = "rutauth-realm"
+	RealmDefaultRole         = "DefaultRole"
+)
+
+// Realm represents a security realm that can be activated in Nexus
+type Realm struct {
+	ID   *string `json:"id"`
+	Name *string `json:"name"`
+}
+
+// ListAvailableRealms returns every realm that can be activated
+func (n *Nexus) ListAvailableRealms() (res []*Realm, err error) {
+	return n.ListAvailableRealmsWithContext(context.Background())
+}
+
+// ListAvailableRealmsWithContext is the same as ListAvailableRealms with the addition of a context
+func (n *Nexus) ListAvailableRealmsWithContext(ctx context.Context) (res []*Realm, err error) {
+	res = make([]*Realm, 0)
+	req, err := n.NewRequestWithContext(ctx, "GET", "service/rest/v1/security/realms/available", nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: "Insufficient permissions to list available realms",
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// ListActiveRealms returns the IDs of the active realms in the order they are consulted
+func (n *Nexus) ListActiveRealms() (res []string, err error) {
+	return n.ListActiveRealmsWithContext(context.Background())
+}
+
+// ListActiveRealmsWithContext is the same as ListActiveRealms with the addition of a context
+func (n *Nexus) ListActiveRealmsWithContext(ctx context.Context) (res []string, err error) {
+	res = make([]string, 0)
+	req, err := n.NewRequestWithContext(ctx, "GET", "service/rest/v1/security/realms/active", nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: "Insufficient permissions to list active realms",
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// SetActiveRealms activates the realms with the given IDs in the given order, and
+// deactivates every other realm. Leaving out RealmLocalAuthenticating makes it
+// impossible to log in with the users managed by Nexus.
+//
+// Example
+//
+// Authenticate against LDAP before the local users, and allow docker logins
+//
+//     err := client.SetActiveRealms([]string{
+//         nexus.RealmLDAP,
+//         nexus.RealmLocalAuthenticating,
+//         nexus.RealmDockerToken,
+//     })
+func (n *Nexus) SetActiveRealms(ids []string) (err error) {
+	return n.SetActiveRealmsWithContext(context.Background(), ids)
+}
+
+// SetActiveRealmsWithContext is the same as SetActiveRealms with the addition of a context
+func (n *Nexus) SetActiveRealmsWithContext(ctx context.Context, ids []string) (err error) {
+	if ids == nil {
+		ids = make([]string, 0)
+	}
+	payload, err := json.Marshal(ids)
+	if err != nil {
+		return
+	}
+	req, err := n.NewRequestWithContext(ctx, "PUT", "service/rest/v1/security/realms/active", nil, payload, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: "Insufficient permissions to set active realms",
+	}, false)
+	return
+}
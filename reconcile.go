@@ -0,0 +1,692 @@
+package nexus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// PlanActionCreate and friends are the actions a PlanChange can take
+const (
+	PlanActionCreate = "create"
+	PlanActionUpdate = "update"
+	PlanActionDelete = "delete"
+)
+
+// PlanKindBlobStore and friends are the kinds of objects a PlanChange can apply to
+const (
+	PlanKindBlobStore       = "blob store"
+	PlanKindCleanupPolicy   = "cleanup policy"
+	PlanKindRepository      = "repository"
+	PlanKindScript          = "script"
+	PlanKindContentSelector = "content selector"
+	PlanKindRoutingRule     = "routing rule"
+	PlanKindPrivilege       = "privilege"
+	PlanKindRole            = "role"
+	PlanKindUser            = "user"
+)
+
+// writeOnlyFields are never returned by Nexus, so comparing them would always
+// produce a difference.
+var writeOnlyFields = map[string]bool{
+	"password":        true,
+	"passphrase":      true,
+	"keypair":         true,
+	"secretAccessKey": true,
+	"sessionToken":    true,
+	"accountKey":      true,
+	"credentialFile":  true,
+}
+
+// DesiredState is the configuration that Plan and Reconcile compare with
+// the state of the Nexus server. It can be decoded from JSON (or YAML using
+// a JSON compatible decoder) with the same field names used by the API.
+type DesiredState struct {
+	BlobStores         []*CreateBlobStoreInput  `json:"blobStores,omitempty"`
+	CleanupPolicies    []*CleanupPolicy         `json:"cleanupPolicies,omitempty"`
+	HostedRepositories []*HostedRepositoryInput `json:"hostedRepositories,omitempty"`
+	ProxyRepositories  []*ProxyRepositoryInput  `json:"proxyRepositories,omitempty"`
+	GroupRepositories  []*GroupRepositoryInput  `json:"groupRepositories,omitempty"`
+}
+
+// ReconcileInput provides parameters to Plan and Reconcile. Objects that exist
+// in Nexus but not in the desired state are only deleted when Prune is true.
+// When DryRun is true Reconcile returns the plan without applying it.
+type ReconcileInput struct {
+	Desired *DesiredState
+	Prune   *bool
+	DryRun  *bool
+}
+
+// PlanChange is a single change in a ReconcilePlan. Diff holds a line for each
+// field that differs when the Action is PlanActionUpdate.
+type PlanChange struct {
+	Action string
+	Kind   string
+	Name   string
+	Diff   []string
+
+	apply func(ctx context.Context) error
+}
+
+// ReconcilePlan is the ordered list of changes needed to bring Nexus to the
+// desired state. Blob stores are created before the cleanup policies and repositories
+// that use them, and group repositories after their members. Deletions happen
+// last and in the reverse order.
+type ReconcilePlan struct {
+	Changes []*PlanChange
+}
+
+// Empty returns true if Nexus already matches the desired state
+func (p *ReconcilePlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String returns a human-readable representation of the plan
+func (p *ReconcilePlan) String() string {
+	if p.Empty() {
+		return "No changes, Nexus matches the desired state\n"
+	}
+	var b strings.Builder
+	counts := make(map[string]int)
+	for _, change := range p.Changes {
+		counts[change.Action]++
+		symbol := "~"
+		switch change.Action {
+		case PlanActionCreate:
+			symbol = "+"
+		case PlanActionDelete:
+			symbol = "-"
+		}
+		fmt.Fprintf(&b, "%s %s %s %q\n", symbol, change.Action, change.Kind, change.Name)
+		for _, line := range change.Diff {
+			fmt.Fprintf(&b, "    %s\n", line)
+		}
+	}
+	fmt.Fprintf(&b, "\nPlan: %d to create, %d to update, %d to delete\n",
+		counts[PlanActionCreate], counts[PlanActionUpdate], counts[PlanActionDelete])
+	return b.String()
+}
+
+// Plan compares the desired state with the blob stores, cleanup policies and
+// repositories in Nexus and returns the changes needed to reconcile them. Only the
+// fields that are set in the desired state are compared, and write-only fields such
+// as passwords are ignored. Existing blob stores are only checked for their type
+// when the server does not support the blob store REST API, since they cannot be
+// updated without it.
+//
+// An update replaces the whole configuration of an object with the desired fields
+// merged over its current configuration, so fields that are not set keep their
+// current value. Nexus never returns write-only fields, such as the password a
+// proxy repository uses to authenticate to its remote, so they are cleared by an
+// update unless they are set in the desired state.
+func (n *Nexus) Plan(input *ReconcileInput) (plan *ReconcilePlan, err error) {
+	return n.PlanWithContext(context.Background(), input)
+}
+
+// PlanWithContext is the same as Plan with the addition of a context
+func (n *Nexus) PlanWithContext(ctx context.Context, input *ReconcileInput) (plan *ReconcilePlan, err error) {
+	if input == nil || input.Desired == nil {
+		err = errors.New("Desired is required for Plan")
+		return
+	}
+	prune := input.Prune != nil && *input.Prune
+	blobChanges, blobDeletes, err := n.planBlobStores(ctx, input.Desired, prune)
+	if err != nil {
+		return
+	}
+	policyChanges, policyDeletes, err := n.planCleanupPolicies(ctx, input.Desired, prune)
+	if err != nil {
+		return
+	}
+	repoChanges, repoDeletes, err := n.planRepositories(ctx, input.Desired, prune)
+	if err != nil {
+		return
+	}
+	plan = &ReconcilePlan{Changes: make([]*PlanChange, 0)}
+	for _, changes := range [][]*PlanChange{
+		blobChanges, policyChanges, repoChanges,
+		repoDeletes, policyDeletes, blobDeletes,
+	} {
+		plan.Changes = append(plan.Changes, changes...)
+	}
+	return
+}
+
+// ApplyPlan applies the changes in a plan in order, stopping at the first failure.
+// Since the plan only contains the differences, applying the plan for a state
+// that has already been reconciled does nothing.
+func (n *Nexus) ApplyPlan(plan *ReconcilePlan) (err error) {
+	return n.ApplyPlanWithContext(context.Background(), plan)
+}
+
+// ApplyPlanWithContext is the same as ApplyPlan with the addition of a context
+func (n *Nexus) ApplyPlanWithContext(ctx context.Context, plan *ReconcilePlan) (err error) {
+	for _, change := range plan.Changes {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+		if err = change.apply(ctx); err != nil {
+			err = fmt.Errorf("Failed to %s %s %s: %w", change.Action, change.Kind, change.Name, err)
+			return
+		}
+	}
+	return
+}
+
+// Reconcile plans the changes needed to reach the desired state and applies
+// them unless DryRun is set. The plan is returned in both cases.
+//
+// Example
+//
+// Ensure a blob store and a maven repository using it exist
+//
+//     plan, err := client.Reconcile(&nexus.ReconcileInput{
+//         Desired: &nexus.DesiredState{
+//             BlobStores: []*nexus.CreateBlobStoreInput{
+//                 {
+//                     Name: nexus.String("maven"),
+//                     Type: nexus.BlobStoreTypeFile,
+//                     Path: nexus.String("maven"),
+//                 },
+//             },
+//             HostedRepositories: []*nexus.HostedRepositoryInput{
+//                 {
+//                     Name:   nexus.String("maven-releases"),
+//                     Format: nexus.RepositoryFormatMaven,
+//                     Online: nexus.Bool(true),
+//                     Storage: &nexus.RepositoryStorage{
+//                         BlobStoreName:               nexus.String("maven"),
+//                         StrictContentTypeValidation: nexus.Bool(true),
+//                         WritePolicy:                 nexus.WritePolicyAllowOnce,
+//                     },
+//                     Maven: &nexus.MavenAttributes{
+//                         VersionPolicy: nexus.MavenVersionPolicyRelease,
+//                         LayoutPolicy:  nexus.MavenLayoutPolicyStrict,
+//                     },
+//                 },
+//             },
+//         },
+//     })
+//     if err != nil {
+//         log.Fatal(err)
+//     }
+//     fmt.Print(plan)
+func (n *Nexus) Reconcile(input *ReconcileInput) (plan *ReconcilePlan, err error) {
+	return n.ReconcileWithContext(context.Background(), input)
+}
+
+// ReconcileWithContext is the same as Reconcile with the addition of a context
+func (n *Nexus) ReconcileWithContext(ctx context.Context, input *ReconcileInput) (plan *ReconcilePlan, err error) {
+	plan, err = n.PlanWithContext(ctx, input)
+	if err != nil {
+		return
+	}
+	if input.DryRun != nil && *input.DryRun {
+		return
+	}
+	err = n.ApplyPlanWithContext(ctx, plan)
+	return
+}
+
+func (n *Nexus) planBlobStores(ctx context.Context, desired *DesiredState, prune bool) (changes, deletes []*PlanChange, err error) {
+	stores, err := n.ListBlobStoresWithContext(ctx)
+	if err != nil {
+		return
+	}
+	current := make(map[string]*BlobStore)
+	for _, store := range stores {
+		if store.Config != nil && store.Config.Name != nil {
+			current[*store.Config.Name] = store
+		}
+	}
+	seen := make(map[string]bool)
+	for _, input := range desired.BlobStores {
+		if input.Name == nil {
+			err = errors.New("Name is required for every blob store in the desired state")
+			return
+		}
+		name := *input.Name
+		if seen[name] {
+			err = fmt.Errorf("Blob store %s is declared more than once", name)
+			return
+		}
+		seen[name] = true
+		store, ok := current[name]
+		if !ok {
+			input := input
+			changes = append(changes, &PlanChange{
+				Action: PlanActionCreate,
+				Kind:   PlanKindBlobStore,
+				Name:   name,
+				apply: func(ctx context.Context) error {
+					_, err := n.CreateBlobStoreWithContext(ctx, input)
+					return err
+				},
+			})
+			continue
+		}
+		if input.Type != nil && store.Config.Type != nil && !strings.EqualFold(*input.Type, *store.Config.Type) {
+			err = fmt.Errorf("Blob store %s is of type %s and cannot be changed to %s", name, *store.Config.Type, *input.Type)
+			return
+		}
+		var change *PlanChange
+		if change, err = n.planBlobStoreUpdate(ctx, input); err != nil {
+			return
+		}
+		changes = appendChange(changes, change)
+	}
+	if !prune {
+		return
+	}
+	for _, name := range sortedKeys(current, seen) {
+		name := name
+		deletes = append(deletes, &PlanChange{
+			Action: PlanActionDelete,
+			Kind:   PlanKindBlobStore,
+			Name:   name,
+			apply: func(ctx context.Context) error {
+				return n.DeleteBlobStoreWithContext(ctx, &DeleteBlobStoreInput{Name: String(name)})
+			},
+		})
+	}
+	return
+}
+
+// planBlobStoreUpdate compares an existing blob store with the desired state
+// when the server supports updating blob stores.
+func (n *Nexus) planBlobStoreUpdate(ctx context.Context, input *CreateBlobStoreInput) (change *PlanChange, err error) {
+	rest, err := n.blobStoreRESTAvailable(ctx)
+	if err != nil || !rest {
+		return
+	}
+	store, err := n.GetBlobStoreWithContext(ctx, *input.Name)
+	if err != nil {
+		return
+	}
+	current := store.createInput()
+	current.Type = input.Type
+	diff, err := diffFields(input, current)
+	if err != nil || len(diff) == 0 {
+		return
+	}
+	merged := &CreateBlobStoreInput{}
+	if err = mergeInput(input, current, merged); err != nil {
+		return
+	}
+	change = &PlanChange{
+		Action: PlanActionUpdate,
+		Kind:   PlanKindBlobStore,
+		Name:   *input.Name,
+		Diff:   diff,
+		apply: func(ctx context.Context) error {
+			_, err := n.UpdateBlobStoreWithContext(ctx, merged)
+			return err
+		},
+	}
+	return
+}
+
+func (n *Nexus) planCleanupPolicies(ctx context.Context, desired *DesiredState, prune bool) (changes, deletes []*PlanChange, err error) {
+	// Avoid requiring the cleanup policy endpoint when policies are not managed
+	if len(desired.CleanupPolicies) == 0 && !prune {
+		return
+	}
+	policies, err := n.ListCleanupPoliciesWithContext(ctx)
+	if err != nil {
+		return
+	}
+	current := make(map[string]*CleanupPolicy)
+	for _, policy := range policies {
+		if policy.Name != nil {
+			current[*policy.Name] = policy
+		}
+	}
+	seen := make(map[string]bool)
+	for _, input := range desired.CleanupPolicies {
+		if input.Name == nil {
+			err = errors.New("Name is required for every cleanup policy in the desired state")
+			return
+		}
+		name := *input.Name
+		if seen[name] {
+			err = fmt.Errorf("Cleanup policy %s is declared more than once", name)
+			return
+		}
+		seen[name] = true
+		input := input
+		policy, ok := current[name]
+		if !ok {
+			changes = append(changes, &PlanChange{
+				Action: PlanActionCreate,
+				Kind:   PlanKindCleanupPolicy,
+				Name:   name,
+				apply: func(ctx context.Context) error {
+					return n.CreateCleanupPolicyWithContext(ctx, input)
+				},
+			})
+			continue
+		}
+		var diff []string
+		if diff, err = diffFields(input, policy); err != nil {
+			return
+		}
+		if len(diff) == 0 {
+			continue
+		}
+		merged := &CleanupPolicy{}
+		if err = mergeInput(input, policy, merged); err != nil {
+			return
+		}
+		changes = append(changes, &PlanChange{
+			Action: PlanActionUpdate,
+			Kind:   PlanKindCleanupPolicy,
+			Name:   name,
+			Diff:   diff,
+			apply: func(ctx context.Context) error {
+				return n.UpdateCleanupPolicyWithContext(ctx, merged)
+			},
+		})
+	}
+	if !prune {
+		return
+	}
+	for _, name := range sortedKeys(current, seen) {
+		name := name
+		deletes = append(deletes, &PlanChange{
+			Action: PlanActionDelete,
+			Kind:   PlanKindCleanupPolicy,
+			Name:   name,
+			apply: func(ctx context.Context) error {
+				return n.DeleteCleanupPolicyWithContext(ctx, name)
+			},
+		})
+	}
+	return
+}
+
+// desiredRepository is a repository from the desired state along with the
+// functions used to create or update it. The update submits merged, which is
+// filled in with the desired input merged over the current configuration.
+type desiredRepository struct {
+	name, repoType string
+	input, merged  interface{}
+	create, update func(ctx context.Context) error
+}
+
+func (n *Nexus) desiredRepositories(desired *DesiredState) (repos []*desiredRepository, err error) {
+	for _, input := range desired.HostedRepositories {
+		input := input
+		merged := &HostedRepositoryInput{}
+		repos = append(repos, &desiredRepository{
+			name: stringValue(input.Name), repoType: "hosted", input: input, merged: merged,
+			create: func(ctx context.Context) error { return n.CreateHostedRepositoryWithContext(ctx, input) },
+			update: func(ctx context.Context) error { return n.UpdateHostedRepositoryWithContext(ctx, merged) },
+		})
+	}
+	for _, input := range desired.ProxyRepositories {
+		input := input
+		merged := &ProxyRepositoryInput{}
+		repos = append(repos, &desiredRepository{
+			name: stringValue(input.Name), repoType: "proxy", input: input, merged: merged,
+			create: func(ctx context.Context) error { return n.CreateProxyRepositoryWithContext(ctx, input) },
+			update: func(ctx context.Context) error { return n.UpdateProxyRepositoryWithContext(ctx, merged) },
+		})
+	}
+	for _, input := range desired.GroupRepositories {
+		input := input
+		merged := &GroupRepositoryInput{}
+		repos = append(repos, &desiredRepository{
+			name: stringValue(input.Name), repoType: "group", input: input, merged: merged,
+			create: func(ctx context.Context) error { return n.CreateGroupRepositoryWithContext(ctx, input) },
+			update: func(ctx context.Context) error { return n.UpdateGroupRepositoryWithContext(ctx, merged) },
+		})
+	}
+	seen := make(map[string]bool)
+	for _, repo := range repos {
+		if repo.name == "" {
+			err = errors.New("Name is required for every repository in the desired state")
+			return
+		}
+		if seen[repo.name] {
+			err = fmt.Errorf("Repository %s is declared more than once", repo.name)
+			return
+		}
+		seen[repo.name] = true
+	}
+	return
+}
+
+func (n *Nexus) planRepositories(ctx context.Context, desired *DesiredState, prune bool) (changes, deletes []*PlanChange, err error) {
+	repos, err := n.desiredRepositories(desired)
+	if err != nil {
+		return
+	}
+	existing, err := n.ListRepositoriesWithContext(ctx)
+	if err != nil {
+		return
+	}
+	current := make(map[string]*Repository)
+	for _, repo := range existing {
+		if repo.Name != nil {
+			current[*repo.Name] = repo
+		}
+	}
+	seen := make(map[string]bool)
+	for _, repo := range repos {
+		seen[repo.name] = true
+		summary, ok := current[repo.name]
+		if !ok {
+			changes = append(changes, &PlanChange{
+				Action: PlanActionCreate,
+				Kind:   PlanKindRepository,
+				Name:   repo.name,
+				apply:  repo.create,
+			})
+			continue
+		}
+		if summary.Type != nil && *summary.Type != repo.repoType {
+			err = fmt.Errorf("Repository %s is a %s repository and cannot be changed to %s", repo.name, *summary.Type, repo.repoType)
+			return
+		}
+		var full *Repository
+		if full, err = n.GetRepositoryWithContext(ctx, repo.name); err != nil {
+			return
+		}
+		var currentInput map[string]interface{}
+		if currentInput, err = repositoryInputValue(full); err != nil {
+			return
+		}
+		var diff []string
+		if diff, err = diffFields(repo.input, currentInput); err != nil {
+			return
+		}
+		if len(diff) == 0 {
+			continue
+		}
+		if err = mergeInput(repo.input, currentInput, repo.merged); err != nil {
+			return
+		}
+		changes = append(changes, &PlanChange{
+			Action: PlanActionUpdate,
+			Kind:   PlanKindRepository,
+			Name:   repo.name,
+			Diff:   diff,
+			apply:  repo.update,
+		})
+	}
+	if !prune {
+		return
+	}
+	// Groups are deleted before the repositories they may contain
+	names := sortedKeys(current, seen)
+	sort.SliceStable(names, func(i, j int) bool {
+		return isGroup(current[names[i]]) && !isGroup(current[names[j]])
+	})
+	for _, name := range names {
+		name := name
+		deletes = append(deletes, &PlanChange{
+			Action: PlanActionDelete,
+			Kind:   PlanKindRepository,
+			Name:   name,
+			apply: func(ctx context.Context) error {
+				return n.DeleteRepositoryWithContext(ctx, name)
+			},
+		})
+	}
+	return
+}
+
+// repositoryInputValue returns the JSON representation of a repository using the
+// field names of the repository inputs, so it can be compared against them.
+func repositoryInputValue(repo *Repository) (value map[string]interface{}, err error) {
+	body, err := json.Marshal(repo)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(body, &value); err != nil {
+		return
+	}
+	// Nexus returns the routing rule of a proxy as routingRuleName, but expects
+	// routingRule when it is created or updated
+	if rule, ok := value["routingRuleName"]; ok {
+		value["routingRule"] = rule
+		delete(value, "routingRuleName")
+	}
+	return
+}
+
+func isGroup(repo *Repository) bool {
+	return repo.Type != nil && *repo.Type == "group"
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// sortedKeys returns the sorted keys of current that are not in seen
+func sortedKeys(current interface{}, seen map[string]bool) (keys []string) {
+	for _, key := range reflect.ValueOf(current).MapKeys() {
+		if !seen[key.String()] {
+			keys = append(keys, key.String())
+		}
+	}
+	sort.Strings(keys)
+	return
+}
+
+// diffFields compares the fields that are set in desired with the same fields
+// in current, after converting both to their JSON representation, and returns a
+// line describing each difference.
+func diffFields(desired, current interface{}) (diff []string, err error) {
+	var d, c interface{}
+	if d, err = toJSONValue(desired); err != nil {
+		return
+	}
+	if c, err = toJSONValue(current); err != nil {
+		return
+	}
+	diff = diffValues("", d, c)
+	return
+}
+
+// mergeInput decodes the fields set in desired merged over current into out, so
+// that an update keeps the current value of the fields desired leaves unset.
+func mergeInput(desired, current, out interface{}) (err error) {
+	var d, c interface{}
+	if d, err = toJSONValue(desired); err != nil {
+		return
+	}
+	if c, err = toJSONValue(current); err != nil {
+		return
+	}
+	body, err := json.Marshal(mergeValues(d, c))
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, out)
+	return
+}
+
+func mergeValues(desired, current interface{}) interface{} {
+	if desired == nil {
+		return current
+	}
+	fields, ok := desired.(map[string]interface{})
+	currentFields, currentOK := current.(map[string]interface{})
+	if !ok || !currentOK {
+		return desired
+	}
+	merged := make(map[string]interface{}, len(currentFields))
+	for key, value := range currentFields {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = mergeValues(value, currentFields[key])
+	}
+	return merged
+}
+
+func toJSONValue(v interface{}) (out interface{}, err error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &out)
+	return
+}
+
+func diffValues(path string, desired, current interface{}) (diff []string) {
+	if desired == nil {
+		return
+	}
+	if fields, ok := desired.(map[string]interface{}); ok {
+		currentFields, _ := current.(map[string]interface{})
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if writeOnlyFields[key] {
+				continue
+			}
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			diff = append(diff, diffValues(fieldPath, fields[key], currentFields[key])...)
+		}
+		return
+	}
+	if isEmptyList(desired) && isEmptyList(current) {
+		return
+	}
+	if !reflect.DeepEqual(desired, current) {
+		diff = append(diff, fmt.Sprintf("%s: %s => %s", path, jsonString(current), jsonString(desired)))
+	}
+	return
+}
+
+func isEmptyList(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	list, ok := v.([]interface{})
+	return ok && len(list) == 0
+}
+
+func jsonString(v interface{}) string {
+	out, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(out)
+}
@@ -0,0 +1,182 @@
+package nexus
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPlanProxyRepositoryWithRoutingRuleIsUnchanged(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/service/rest/v1/blobstores":
+			w.Write([]byte(`[]`))
+		case "/service/rest/v1/repositories":
+			w.Write([]byte(`[{"name":"npm-proxy","format":"npm","type":"proxy"}]`))
+		case "/service/rest/v1/repositories/npm-proxy":
+			w.Write([]byte(`{"name":"npm-proxy","format":"npm","type":"proxy"}`))
+		case "/service/rest/v1/repositories/npm/proxy/npm-proxy":
+			w.Write([]byte(`{
+				"name": "npm-proxy",
+				"format": "npm",
+				"type": "proxy",
+				"online": true,
+				"storage": {"blobStoreName": "default", "strictContentTypeValidation": true},
+				"proxy": {"remoteUrl": "https://registry.npmjs.org", "contentMaxAge": 1440, "metadataMaxAge": 1440},
+				"routingRuleName": "block-internal"
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewWithOptions(srv.URL, WithoutStatusCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := client.Plan(&ReconcileInput{
+		Desired: &DesiredState{
+			ProxyRepositories: []*ProxyRepositoryInput{{
+				Name:   String("npm-proxy"),
+				Format: RepositoryFormatNpm,
+				Online: Bool(true),
+				Storage: &RepositoryStorage{
+					BlobStoreName:               String("default"),
+					StrictContentTypeValidation: Bool(true),
+				},
+				Proxy: &RepositoryProxy{
+					RemoteURL:      String("https://registry.npmjs.org"),
+					ContentMaxAge:  Int(1440),
+					MetadataMaxAge: Int(1440),
+				},
+				RoutingRule: String("block-internal"),
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Errorf("Expected no changes, got:\n%s", plan)
+	}
+}
+
+func TestPlanProxyRepositoryRoutingRuleChange(t *testing.T) {
+	value, err := repositoryInputValue(&Repository{Name: String("npm-proxy"), RoutingRule: String("old")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff, err := diffFields(&ProxyRepositoryInput{Name: String("npm-proxy"), RoutingRule: String("new")}, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff) != 1 || diff[0] != `routingRule: "old" => "new"` {
+		t.Errorf("Unexpected diff %v", diff)
+	}
+}
+
+func TestReconcileUpdateKeepsUnsetRepositoryFields(t *testing.T) {
+	var put map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "PUT" && r.URL.Path == "/service/rest/v1/repositories/npm/proxy/npm-proxy":
+			if err := json.NewDecoder(r.Body).Decode(&put); err != nil {
+				t.Error(err)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/service/rest/v1/blobstores":
+			w.Write([]byte(`[]`))
+		case r.URL.Path == "/service/rest/v1/repositories":
+			w.Write([]byte(`[{"name":"npm-proxy","format":"npm","type":"proxy"}]`))
+		case r.URL.Path == "/service/rest/v1/repositories/npm-proxy":
+			w.Write([]byte(`{"name":"npm-proxy","format":"npm","type":"proxy"}`))
+		case r.URL.Path == "/service/rest/v1/repositories/npm/proxy/npm-proxy":
+			w.Write([]byte(`{
+				"name": "npm-proxy",
+				"format": "npm",
+				"type": "proxy",
+				"online": true,
+				"storage": {"blobStoreName": "npm", "strictContentTypeValidation": true},
+				"proxy": {"remoteUrl": "https://registry.npmjs.org", "contentMaxAge": 1440, "metadataMaxAge": 1440},
+				"routingRuleName": "block-internal"
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewWithOptions(srv.URL, WithoutStatusCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := client.Reconcile(&ReconcileInput{
+		Desired: &DesiredState{
+			ProxyRepositories: []*ProxyRepositoryInput{{
+				Name:   String("npm-proxy"),
+				Format: RepositoryFormatNpm,
+				Proxy:  &RepositoryProxy{ContentMaxAge: Int(60)},
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Changes) != 1 || len(plan.Changes[0].Diff) != 1 {
+		t.Fatalf("Expected a single update, got:\n%s", plan)
+	}
+	if put == nil {
+		t.Fatal("The repository was not updated")
+	}
+	proxy, _ := put["proxy"].(map[string]interface{})
+	storage, _ := put["storage"].(map[string]interface{})
+	for field, expected := range map[string]interface{}{
+		"online":                true,
+		"routingRule":           "block-internal",
+		"proxy.remoteUrl":       "https://registry.npmjs.org",
+		"proxy.contentMaxAge":   float64(60),
+		"proxy.metadataMaxAge":  float64(1440),
+		"storage.blobStoreName": "npm",
+	} {
+		var actual interface{}
+		switch {
+		case strings.HasPrefix(field, "proxy."):
+			actual = proxy[strings.TrimPrefix(field, "proxy.")]
+		case strings.HasPrefix(field, "storage."):
+			actual = storage[strings.TrimPrefix(field, "storage.")]
+		default:
+			actual = put[field]
+		}
+		if actual != expected {
+			t.Errorf("Expected %s to be %v, got %v", field, expected, actual)
+		}
+	}
+}
+
+func TestMergeValues(t *testing.T) {
+	for _, tc := range []struct {
+		desired, current, expected string
+	}{
+		{`{"a":1}`, `{"a":2,"b":3}`, `{"a":1,"b":3}`},
+		{`{"a":{"b":1}}`, `{"a":{"b":2,"c":3}}`, `{"a":{"b":1,"c":3}}`},
+		{`{"a":null}`, `{"a":2}`, `{"a":2}`},
+		{`{"a":[1]}`, `{"a":[2,3]}`, `{"a":[1]}`},
+		{`{"a":{"b":1}}`, `{"a":"x"}`, `{"a":{"b":1}}`},
+		{`{"a":1}`, `null`, `{"a":1}`},
+	} {
+		var desired, current, expected interface{}
+		for _, v := range []struct {
+			body string
+			out  *interface{}
+		}{{tc.desired, &desired}, {tc.current, &current}, {tc.expected, &expected}} {
+			if err := json.Unmarshal([]byte(v.body), v.out); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if actual := mergeValues(desired, current); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("mergeValues(%s, %s): expected %v, got %v", tc.desired, tc.current, expected, actual)
+		}
+	}
+}
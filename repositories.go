@@ -1,21 +1,205 @@
 package nexus
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 )
 
-// Repository represents a Nexus repository
+// RepositoryTypeHosted, RepositoryTypeProxy and RepositoryTypeGroup are the
+// values of Repository.Type
+var (
+	RepositoryTypeHosted = String("hosted")
+	RepositoryTypeProxy  = String("proxy")
+	RepositoryTypeGroup  = String("group")
+)
+
+// WritePolicyAllow and friends are used when specifying RepositoryStorage.WritePolicy
+var (
+	WritePolicyAllow     = String("ALLOW")
+	WritePolicyAllowOnce = String("ALLOW_ONCE")
+	WritePolicyDeny      = String("DENY")
+)
+
+// HTTPClientAuthTypeUsername and HTTPClientAuthTypeNTLM are used when specifying
+// RepositoryHTTPClientAuthentication.Type
+var (
+	HTTPClientAuthTypeUsername = String("username")
+	HTTPClientAuthTypeNTLM     = String("ntlm")
+)
+
+// Repository represents a Nexus repository. ListRepositories only populates
+// the name, format, type and URL, while GetRepository returns the full configuration
+// including the attributes specific to the repository's format.
 type Repository struct {
-	Name   *string `json:"name"`
-	Format *string `json:"format"`
-	Type   *string `json:"type"`
-	URL    *string `json:"url"`
+	Name          *string                  `json:"name"`
+	Format        *string                  `json:"format"`
+	Type          *string                  `json:"type"`
+	URL           *string                  `json:"url"`
+	Online        *bool                    `json:"online,omitempty"`
+	Storage       *RepositoryStorage       `json:"storage,omitempty"`
+	Cleanup       *RepositoryCleanup       `json:"cleanup,omitempty"`
+	Proxy         *RepositoryProxy         `json:"proxy,omitempty"`
+	NegativeCache *RepositoryNegativeCache `json:"negativeCache,omitempty"`
+	HTTPClient    *RepositoryHTTPClient    `json:"httpClient,omitempty"`
+	RoutingRule   *string                  `json:"routingRuleName,omitempty"`
+	Group         *RepositoryGroup         `json:"group,omitempty"`
+
+	Maven       *MavenAttributes       `json:"maven,omitempty"`
+	Docker      *DockerAttributes      `json:"docker,omitempty"`
+	DockerProxy *DockerProxyAttributes `json:"dockerProxy,omitempty"`
+	Apt         *AptAttributes         `json:"apt,omitempty"`
+	AptSigning  *AptSigningAttributes  `json:"aptSigning,omitempty"`
+	Yum         *YumAttributes         `json:"yum,omitempty"`
+	Raw         *RawAttributes         `json:"raw,omitempty"`
+	Npm         *NpmAttributes         `json:"npm,omitempty"`
+	Pypi        *PypiAttributes        `json:"pypi,omitempty"`
+}
+
+// RepositoryStorage is the storage configuration of a repository. WritePolicy only
+// applies to hosted repositories and must be one of WritePolicyAllow,
+// WritePolicyAllowOnce or WritePolicyDeny.
+type RepositoryStorage struct {
+	BlobStoreName               *string `json:"blobStoreName,omitempty"`
+	StrictContentTypeValidation *bool   `json:"strictContentTypeValidation,omitempty"`
+	WritePolicy                 *string `json:"writePolicy,omitempty"`
+}
+
+// RepositoryCleanup lists the cleanup policies applied to a repository
+type RepositoryCleanup struct {
+	PolicyNames []string `json:"policyNames"`
+}
+
+// RepositoryProxy is the remote a proxy repository retrieves content from.
+// The max ages are in minutes, -1 caches content indefinitely.
+type RepositoryProxy struct {
+	RemoteURL      *string `json:"remoteUrl,omitempty"`
+	ContentMaxAge  *int    `json:"contentMaxAge,omitempty"`
+	MetadataMaxAge *int    `json:"metadataMaxAge,omitempty"`
+}
+
+// RepositoryNegativeCache configures caching of missing content for a proxy
+// repository. TimeToLive is in minutes.
+type RepositoryNegativeCache struct {
+	Enabled    *bool `json:"enabled,omitempty"`
+	TimeToLive *int  `json:"timeToLive,omitempty"`
+}
+
+// RepositoryHTTPClient configures how a proxy repository connects to its remote
+type RepositoryHTTPClient struct {
+	Blocked        *bool                               `json:"blocked,omitempty"`
+	AutoBlock      *bool                               `json:"autoBlock,omitempty"`
+	Connection     *RepositoryHTTPClientConnection     `json:"connection,omitempty"`
+	Authentication *RepositoryHTTPClientAuthentication `json:"authentication,omitempty"`
+}
+
+// RepositoryHTTPClientConnection holds the connection settings of a proxy
+// repository. Timeout is in seconds.
+type RepositoryHTTPClientConnection struct {
+	Retries                 *int    `json:"retries,omitempty"`
+	UserAgentSuffix         *string `json:"userAgentSuffix,omitempty"`
+	Timeout                 *int    `json:"timeout,omitempty"`
+	EnableCircularRedirects *bool   `json:"enableCircularRedirects,omitempty"`
+	EnableCookies           *bool   `json:"enableCookies,omitempty"`
+	UseTrustStore           *bool   `json:"useTrustStore,omitempty"`
+}
+
+// RepositoryHTTPClientAuthentication holds the credentials a proxy repository uses
+// with its remote. Type must be one of HTTPClientAuthTypeUsername or HTTPClientAuthTypeNTLM.
+// Nexus never returns the password.
+type RepositoryHTTPClientAuthentication struct {
+	Type       *string `json:"type,omitempty"`
+	Username   *string `json:"username,omitempty"`
+	Password   *string `json:"password,omitempty"`
+	NTLMHost   *string `json:"ntlmHost,omitempty"`
+	NTLMDomain *string `json:"ntlmDomain,omitempty"`
+}
+
+// RepositoryGroup lists the members of a group repository, in the order they
+// are searched.
+type RepositoryGroup struct {
+	MemberNames    []string `json:"memberNames"`
+	WritableMember *string  `json:"writableMember,omitempty"`
+}
+
+// HostedRepositoryInput is used to provide parameters to CreateHostedRepository and
+// UpdateHostedRepository. Name, Format, Online and Storage are required, along with
+// the attributes for the format: Maven for maven2, Docker for docker, Apt and
+// AptSigning for apt, and Yum for yum. Raw is optional for raw repositories, while
+// helm, npm and pypi hosted repositories have no format-specific attributes.
+type HostedRepositoryInput struct {
+	Name    *string            `json:"name"`
+	Format  *string            `json:"format,omitempty"`
+	Online  *bool              `json:"online"`
+	Storage *RepositoryStorage `json:"storage"`
+	Cleanup *RepositoryCleanup `json:"cleanup,omitempty"`
+
+	Maven      *MavenAttributes      `json:"maven,omitempty"`
+	Docker     *DockerAttributes     `json:"docker,omitempty"`
+	Apt        *AptAttributes        `json:"apt,omitempty"`
+	AptSigning *AptSigningAttributes `json:"aptSigning,omitempty"`
+	Yum        *YumAttributes        `json:"yum,omitempty"`
+	Raw        *RawAttributes        `json:"raw,omitempty"`
+}
+
+// ProxyRepositoryInput is used to provide parameters to CreateProxyRepository and
+// UpdateProxyRepository. Name, Format, Online, Storage, Proxy, NegativeCache
+// and HTTPClient are required, along with the attributes for the format: Maven for
+// maven2, Docker and DockerProxy for docker, and Apt for apt. Raw, Npm and Pypi are
+// optional for their formats, while helm and yum proxies have no format-specific attributes.
+type ProxyRepositoryInput struct {
+	Name          *string                  `json:"name"`
+	Format        *string                  `json:"format,omitempty"`
+	Online        *bool                    `json:"online"`
+	Storage       *RepositoryStorage       `json:"storage"`
+	Cleanup       *RepositoryCleanup       `json:"cleanup,omitempty"`
+	Proxy         *RepositoryProxy         `json:"proxy"`
+	NegativeCache *RepositoryNegativeCache `json:"negativeCache"`
+	HTTPClient    *RepositoryHTTPClient    `json:"httpClient"`
+	RoutingRule   *string                  `json:"routingRule,omitempty"`
+
+	Maven       *MavenAttributes       `json:"maven,omitempty"`
+	Docker      *DockerAttributes      `json:"docker,omitempty"`
+	DockerProxy *DockerProxyAttributes `json:"dockerProxy,omitempty"`
+	Apt         *AptAttributes         `json:"apt,omitempty"`
+	Raw         *RawAttributes         `json:"raw,omitempty"`
+	Npm         *NpmAttributes         `json:"npm,omitempty"`
+	Pypi        *PypiAttributes        `json:"pypi,omitempty"`
+}
+
+// GroupRepositoryInput is used to provide parameters to CreateGroupRepository and
+// UpdateGroupRepository. Name, Format, Online, Storage and Group are required, as
+// is Docker for docker groups. Raw is optional for raw groups.
+type GroupRepositoryInput struct {
+	Name    *string            `json:"name"`
+	Format  *string            `json:"format,omitempty"`
+	Online  *bool              `json:"online"`
+	Storage *RepositoryStorage `json:"storage"`
+	Group   *RepositoryGroup   `json:"group"`
+
+	Docker *DockerAttributes `json:"docker,omitempty"`
+	Raw    *RawAttributes    `json:"raw,omitempty"`
+}
+
+// repositoryFormatPath returns the name of a format as used in the repository
+// management endpoints, which differs from the format reported for maven.
+func repositoryFormatPath(format string) string {
+	if format == "maven2" {
+		return "maven"
+	}
+	return format
 }
 
 // ListRepositories returns a list of the repositories available in Nexus
 func (n *Nexus) ListRepositories() (res []*Repository, err error) {
+	return n.ListRepositoriesWithContext(context.Background())
+}
+
+// ListRepositoriesWithContext is the same as ListRepositories with the addition of a context
+func (n *Nexus) ListRepositoriesWithContext(ctx context.Context) (res []*Repository, err error) {
 	res = make([]*Repository, 0)
-	req, err := n.NewRequest("GET", "service/rest/v1/repositories", nil, nil, "")
+	req, err := n.NewRequestWithContext(ctx, "GET", "service/rest/v1/repositories", nil, nil, "")
 	if err != nil {
 		return
 	}
@@ -26,3 +210,205 @@ func (n *Nexus) ListRepositories() (res []*Repository, err error) {
 	err = json.Unmarshal(body, &res)
 	return
 }
+
+// GetRepository retrieves the full configuration of a repository by name. For
+// formats Nexus does not expose the configuration of, or when Nexus does not report
+// the format and type of the repository, only the fields of the summary are populated.
+func (n *Nexus) GetRepository(name string) (res *Repository, err error) {
+	return n.GetRepositoryWithContext(context.Background(), name)
+}
+
+// GetRepositoryWithContext is the same as GetRepository with the addition of a context
+func (n *Nexus) GetRepositoryWithContext(ctx context.Context, name string) (res *Repository, err error) {
+	statusMap := map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to get repository %s", name),
+		404: fmt.Sprintf("Repository %s does not exist", name),
+	}
+	endpoint := fmt.Sprintf("service/rest/v1/repositories/%s", name)
+	req, err := n.NewRequestWithContext(ctx, "GET", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, statusMap, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return
+	}
+	if res == nil {
+		err = fmt.Errorf("No repository was returned for %s", name)
+		return
+	}
+	if res.Format == nil || res.Type == nil {
+		// The typed endpoint cannot be found without both, return the summary
+		return
+	}
+	endpoint = fmt.Sprintf("service/rest/v1/repositories/%s/%s/%s", repositoryFormatPath(*res.Format), *res.Type, name)
+	req, err = n.NewRequestWithContext(ctx, "GET", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err = n.Do(req, statusMap, false)
+	if errors.Is(err, ErrNotFound) {
+		// The format has no typed endpoint, return what we know
+		err = nil
+		return
+	} else if err != nil {
+		return
+	}
+	full := &Repository{}
+	err = json.Unmarshal(body, full)
+	if err != nil {
+		return
+	}
+	if full.URL == nil {
+		full.URL = res.URL
+	}
+	res = full
+	return
+}
+
+// DeleteRepository removes the repository with the given name and all of its content.
+func (n *Nexus) DeleteRepository(name string) (err error) {
+	return n.DeleteRepositoryWithContext(context.Background(), name)
+}
+
+// DeleteRepositoryWithContext is the same as DeleteRepository with the addition of a context
+func (n *Nexus) DeleteRepositoryWithContext(ctx context.Context, name string) (err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/repositories/%s", name)
+	req, err := n.NewRequestWithContext(ctx, "DELETE", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to delete repository %s", name),
+		404: fmt.Sprintf("Repository %s does not exist", name),
+	}, false)
+	return
+}
+
+// writeRepository creates a repository of the given type when create is true,
+// otherwise it updates the existing repository. The format is removed from the
+// payload by the caller since it is part of the endpoint.
+func (n *Nexus) writeRepository(ctx context.Context, create bool, repoType string, name *string, format *string, payload interface{}) (err error) {
+	if name == nil || format == nil {
+		err = errors.New("Name and Format are required for a repository")
+		return
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	method := "PUT"
+	endpoint := fmt.Sprintf("service/rest/v1/repositories/%s/%s/%s", repositoryFormatPath(*format), repoType, *name)
+	statusMap := map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to update repository %s", *name),
+		404: fmt.Sprintf("Repository %s does not exist", *name),
+	}
+	if create {
+		method = "POST"
+		endpoint = fmt.Sprintf("service/rest/v1/repositories/%s/%s", repositoryFormatPath(*format), repoType)
+		statusMap = map[int]string{
+			403: fmt.Sprintf("Insufficient permissions to create repository %s", *name),
+		}
+	}
+	req, err := n.NewRequestWithContext(ctx, method, endpoint, nil, body, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, statusMap, false)
+	return
+}
+
+// CreateHostedRepository creates a new hosted repository with the given parameters
+//
+// Example
+//
+// Create a maven repository that only allows releases to be deployed once
+//
+//     err := client.CreateHostedRepository(&nexus.HostedRepositoryInput{
+//         Name:   nexus.String("maven-releases"),
+//         Format: nexus.RepositoryFormatMaven,
+//         Online: nexus.Bool(true),
+//         Storage: &nexus.RepositoryStorage{
+//             BlobStoreName:               nexus.String("default"),
+//             StrictContentTypeValidation: nexus.Bool(true),
+//             WritePolicy:                 nexus.WritePolicyAllowOnce,
+//         },
+//         Maven: &nexus.MavenAttributes{
+//             VersionPolicy: nexus.MavenVersionPolicyRelease,
+//             LayoutPolicy:  nexus.MavenLayoutPolicyStrict,
+//         },
+//     })
+func (n *Nexus) CreateHostedRepository(input *HostedRepositoryInput) (err error) {
+	return n.CreateHostedRepositoryWithContext(context.Background(), input)
+}
+
+// CreateHostedRepositoryWithContext is the same as CreateHostedRepository with the addition of a context
+func (n *Nexus) CreateHostedRepositoryWithContext(ctx context.Context, input *HostedRepositoryInput) (err error) {
+	payload := *input
+	payload.Format = nil
+	return n.writeRepository(ctx, true, "hosted", input.Name, input.Format, &payload)
+}
+
+// UpdateHostedRepository replaces the configuration of an existing hosted repository
+func (n *Nexus) UpdateHostedRepository(input *HostedRepositoryInput) (err error) {
+	return n.UpdateHostedRepositoryWithContext(context.Background(), input)
+}
+
+// UpdateHostedRepositoryWithContext is the same as UpdateHostedRepository with the addition of a context
+func (n *Nexus) UpdateHostedRepositoryWithContext(ctx context.Context, input *HostedRepositoryInput) (err error) {
+	payload := *input
+	payload.Format = nil
+	return n.writeRepository(ctx, false, "hosted", input.Name, input.Format, &payload)
+}
+
+// CreateProxyRepository creates a new proxy repository with the given parameters
+func (n *Nexus) CreateProxyRepository(input *ProxyRepositoryInput) (err error) {
+	return n.CreateProxyRepositoryWithContext(context.Background(), input)
+}
+
+// CreateProxyRepositoryWithContext is the same as CreateProxyRepository with the addition of a context
+func (n *Nexus) CreateProxyRepositoryWithContext(ctx context.Context, input *ProxyRepositoryInput) (err error) {
+	payload := *input
+	payload.Format = nil
+	return n.writeRepository(ctx, true, "proxy", input.Name, input.Format, &payload)
+}
+
+// UpdateProxyRepository replaces the configuration of an existing proxy repository
+func (n *Nexus) UpdateProxyRepository(input *ProxyRepositoryInput) (err error) {
+	return n.UpdateProxyRepositoryWithContext(context.Background(), input)
+}
+
+// UpdateProxyRepositoryWithContext is the same as UpdateProxyRepository with the addition of a context
+func (n *Nexus) UpdateProxyRepositoryWithContext(ctx context.Context, input *ProxyRepositoryInput) (err error) {
+	payload := *input
+	payload.Format = nil
+	return n.writeRepository(ctx, false, "proxy", input.Name, input.Format, &payload)
+}
+
+// CreateGroupRepository creates a new group repository with the given parameters
+func (n *Nexus) CreateGroupRepository(input *GroupRepositoryInput) (err error) {
+	return n.CreateGroupRepositoryWithContext(context.Background(), input)
+}
+
+// CreateGroupRepositoryWithContext is the same as CreateGroupRepository with the addition of a context
+func (n *Nexus) CreateGroupRepositoryWithContext(ctx context.Context, input *GroupRepositoryInput) (err error) {
+	payload := *input
+	payload.Format = nil
+	return n.writeRepository(ctx, true, "group", input.Name, input.Format, &payload)
+}
+
+// UpdateGroupRepository replaces the configuration of an existing group repository
+func (n *Nexus) UpdateGroupRepository(input *GroupRepositoryInput) (err error) {
+	return n.UpdateGroupRepositoryWithContext(context.Background(), input)
+}
+
+// UpdateGroupRepositoryWithContext is the same as UpdateGroupRepository with the addition of a context
+func (n *Nexus) UpdateGroupRepositoryWithContext(ctx context.Context, input *GroupRepositoryInput) (err error) {
+	payload := *input
+	payload.Format = nil
+	return n.writeRepository(ctx, false, "group", input.Name, input.Format, &payload)
+}
@@ -0,0 +1,58 @@
+package nexus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetRepository(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/service/rest/v1/repositories/maven-releases":
+			w.Write([]byte(`{"name":"maven-releases","format":"maven2","type":"hosted","url":"http://nexus/repository/maven-releases"}`))
+		case "/service/rest/v1/repositories/maven/hosted/maven-releases":
+			w.Write([]byte(`{"name":"maven-releases","format":"maven2","type":"hosted","online":true}`))
+		case "/service/rest/v1/repositories/sparse":
+			w.Write([]byte(`{"name":"sparse","type":"hosted"}`))
+		case "/service/rest/v1/repositories/empty":
+			w.Write([]byte(`null`))
+		case "/service/rest/v1/repositories/conan":
+			w.Write([]byte(`{"name":"conan","format":"conan","type":"proxy"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewWithOptions(srv.URL, WithoutStatusCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		name   string
+		online bool
+		url    string
+		fails  bool
+	}{
+		{name: "maven-releases", online: true, url: "http://nexus/repository/maven-releases"},
+		{name: "sparse"},
+		{name: "conan"},
+		{name: "empty", fails: true},
+		{name: "missing", fails: true},
+	} {
+		repo, err := client.GetRepository(tc.name)
+		if tc.fails {
+			if err == nil {
+				t.Errorf("%s: expected an error", tc.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if stringValue(repo.Name) != tc.name || (repo.Online != nil && *repo.Online) != tc.online || stringValue(repo.URL) != tc.url {
+			t.Errorf("%s: unexpected repository %+v", tc.name, repo)
+		}
+	}
+}
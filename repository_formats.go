@@ -0,0 +1,112 @@
+package nexus
+
+// RepositoryFormatMaven and friends are used when specifying the Format of a repository
+var (
+	RepositoryFormatApt    = String("apt")
+	RepositoryFormatDocker = String("docker")
+	RepositoryFormatHelm   = String("helm")
+	RepositoryFormatMaven  = String("maven2")
+	RepositoryFormatNpm    = String("npm")
+	RepositoryFormatPypi   = String("pypi")
+	RepositoryFormatRaw    = String("raw")
+	RepositoryFormatYum    = String("yum")
+)
+
+// MavenVersionPolicyRelease and friends are used when specifying MavenAttributes.VersionPolicy
+var (
+	MavenVersionPolicyRelease  = String("RELEASE")
+	MavenVersionPolicySnapshot = String("SNAPSHOT")
+	MavenVersionPolicyMixed    = String("MIXED")
+)
+
+// MavenLayoutPolicyStrict and MavenLayoutPolicyPermissive are used when specifying
+// MavenAttributes.LayoutPolicy
+var (
+	MavenLayoutPolicyStrict     = String("STRICT")
+	MavenLayoutPolicyPermissive = String("PERMISSIVE")
+)
+
+// ContentDispositionInline and ContentDispositionAttachment are used when specifying
+// the ContentDisposition of maven and raw repositories
+var (
+	ContentDispositionInline     = String("INLINE")
+	ContentDispositionAttachment = String("ATTACHMENT")
+)
+
+// DockerIndexTypeHub and friends are used when specifying DockerProxyAttributes.IndexType
+var (
+	DockerIndexTypeHub      = String("HUB")
+	DockerIndexTypeRegistry = String("REGISTRY")
+	DockerIndexTypeCustom   = String("CUSTOM")
+)
+
+// YumDeployPolicyStrict and YumDeployPolicyPermissive are used when specifying
+// YumAttributes.DeployPolicy
+var (
+	YumDeployPolicyStrict     = String("STRICT")
+	YumDeployPolicyPermissive = String("PERMISSIVE")
+)
+
+// MavenAttributes are required for maven hosted and proxy repositories
+type MavenAttributes struct {
+	VersionPolicy      *string `json:"versionPolicy,omitempty"`
+	LayoutPolicy       *string `json:"layoutPolicy,omitempty"`
+	ContentDisposition *string `json:"contentDisposition,omitempty"`
+}
+
+// DockerAttributes are required for docker repositories of every type. The
+// connector ports are optional and expose the repository on its own port.
+type DockerAttributes struct {
+	V1Enabled      *bool   `json:"v1Enabled,omitempty"`
+	ForceBasicAuth *bool   `json:"forceBasicAuth,omitempty"`
+	HTTPPort       *int    `json:"httpPort,omitempty"`
+	HTTPSPort      *int    `json:"httpsPort,omitempty"`
+	Subdomain      *string `json:"subdomain,omitempty"`
+}
+
+// DockerProxyAttributes are required for docker proxy repositories. IndexURL is
+// only used with DockerIndexTypeCustom.
+type DockerProxyAttributes struct {
+	IndexType                *string  `json:"indexType,omitempty"`
+	IndexURL                 *string  `json:"indexUrl,omitempty"`
+	CacheForeignLayers       *bool    `json:"cacheForeignLayers,omitempty"`
+	ForeignLayerURLWhitelist []string `json:"foreignLayerUrlWhitelist,omitempty"`
+}
+
+// AptAttributes are required for apt hosted and proxy repositories. Flat only
+// applies to proxies of flat repositories.
+type AptAttributes struct {
+	Distribution *string `json:"distribution,omitempty"`
+	Flat         *bool   `json:"flat,omitempty"`
+}
+
+// AptSigningAttributes are required for apt hosted repositories. Keypair is the
+// PGP signing key pair in armored format.
+type AptSigningAttributes struct {
+	Keypair    *string `json:"keypair,omitempty"`
+	Passphrase *string `json:"passphrase,omitempty"`
+}
+
+// YumAttributes are required for yum hosted repositories
+type YumAttributes struct {
+	RepodataDepth *int    `json:"repodataDepth,omitempty"`
+	DeployPolicy  *string `json:"deployPolicy,omitempty"`
+}
+
+// RawAttributes are used by raw repositories of every type
+type RawAttributes struct {
+	ContentDisposition *string `json:"contentDisposition,omitempty"`
+}
+
+// NpmAttributes are used by npm proxy repositories to filter quarantined and
+// uncataloged packages when Nexus Firewall is enabled.
+type NpmAttributes struct {
+	RemoveNonCataloged *bool `json:"removeNonCataloged,omitempty"`
+	RemoveQuarantined  *bool `json:"removeQuarantined,omitempty"`
+}
+
+// PypiAttributes are used by pypi proxy repositories to filter quarantined
+// packages when Nexus Firewall is enabled.
+type PypiAttributes struct {
+	RemoveQuarantined *bool `json:"removeQuarantined,omitempty"`
+}
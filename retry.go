@@ -0,0 +1,223 @@
+package nexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how requests that fail with a transient error are retried.
+// Requests are only retried when their body can be replayed. By default only
+// idempotent methods are retried, with the exception of component uploads which
+// are always safe to replay.
+//
+// Example
+//
+// Retry up to 5 times and log every retry
+//
+//     policy := nexus.DefaultRetryPolicy()
+//     policy.MaxAttempts = 5
+//     policy.OnRetry = func(ev *nexus.RetryEvent) {
+//         log.Printf("Retrying %s %s in %s: %s", ev.Method, ev.URL, ev.Delay, ev.Reason)
+//     }
+//     client, err := nexus.NewWithOptions("http://localhost:8081",
+//         nexus.WithCredentials("admin", "admin123"),
+//         nexus.WithRetryPolicy(policy),
+//     )
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request, including
+	// the first one. A value of 1 or less disables retries.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry. It doubles on every attempt.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between attempts, including delays requested by
+	// a Retry-After header.
+	MaxBackoff time.Duration
+	// RetryableStatusCodes are the response codes that are retried.
+	RetryableStatusCodes []int
+	// RetryNonIdempotent allows retrying methods such as POST.
+	RetryNonIdempotent bool
+	// OnRetry, if set, is called before sleeping ahead of each retry.
+	OnRetry func(event *RetryEvent)
+}
+
+// RetryEvent describes a failed attempt that is about to be retried.
+type RetryEvent struct {
+	// Attempt is the number of the attempt that failed, starting at 1.
+	Attempt int
+	// Method is the HTTP method of the request.
+	Method string
+	// URL is the full URL of the request.
+	URL string
+	// StatusCode is the status of the failed response, or 0 on a connection error.
+	StatusCode int
+	// Err is the connection error, if any.
+	Err error
+	// Delay is how long the client waits before the next attempt.
+	Delay time.Duration
+	// Reason is a human-readable description of why the request is retried.
+	Reason string
+}
+
+// DefaultRetryPolicy returns a policy making up to 4 attempts with backoff between
+// 500ms and 10s, retrying 429, 502, 503 and 504 responses and connection errors.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// WithRetryPolicy retries transient failures according to the given policy.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(o *clientOptions) error {
+		o.retryPolicy = policy
+		return nil
+	}
+}
+
+type replayableKey struct{}
+
+// withReplayable marks requests built with the returned context as safe to
+// retry regardless of their method.
+func withReplayable(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replayableKey{}, true)
+}
+
+func (p *RetryPolicy) allowsRequest(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if p.RetryNonIdempotent {
+		return true
+	}
+	if replayable, _ := req.Context().Value(replayableKey{}).(bool); replayable {
+		return true
+	}
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE", "TRACE":
+		return true
+	}
+	return false
+}
+
+// retryReason returns why the outcome of an attempt should be retried, or an
+// empty string if it should not.
+func (p *RetryPolicy) retryReason(resp *http.Response, err error) string {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return ""
+		}
+		return fmt.Sprintf("connection error: %s", err.Error())
+	}
+	for _, code := range p.RetryableStatusCodes {
+		if resp.StatusCode == code {
+			return fmt.Sprintf("received status code %v", resp.StatusCode)
+		}
+	}
+	return ""
+}
+
+// delay returns the time to wait after the given attempt, honoring a
+// Retry-After header on the response when one is present. Either way the delay
+// never exceeds MaxBackoff.
+func (p *RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if after := parseRetryAfter(resp.Header.Get("Retry-After")); after > 0 {
+			if p.MaxBackoff > 0 && after > p.MaxBackoff {
+				after = p.MaxBackoff
+			}
+			return after
+		}
+	}
+	backoff := p.MinBackoff
+	for i := 1; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	// Equal jitter: wait at least half the backoff so retries still spread out
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1))
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+// send performs the request, retrying it according to the client's retry policy.
+func (n *Nexus) send(req *http.Request) (resp *http.Response, err error) {
+	policy := n.retryPolicy
+	if policy == nil || policy.MaxAttempts <= 1 || !policy.allowsRequest(req) {
+		return n.client.Do(req)
+	}
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 {
+			attemptReq = req.Clone(ctx)
+			if req.GetBody != nil {
+				if attemptReq.Body, err = req.GetBody(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		resp, err = n.client.Do(attemptReq)
+		if attempt >= policy.MaxAttempts {
+			return
+		}
+		reason := policy.retryReason(resp, err)
+		if reason == "" {
+			return
+		}
+		event := &RetryEvent{
+			Attempt: attempt,
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Err:     err,
+			Delay:   policy.delay(attempt, resp),
+			Reason:  reason,
+		}
+		if resp != nil {
+			event.StatusCode = resp.StatusCode
+			io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxErrorBodySize))
+			resp.Body.Close()
+		}
+		if policy.OnRetry != nil {
+			policy.OnRetry(event)
+		}
+		timer := time.NewTimer(event.Delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
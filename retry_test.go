@@ -0,0 +1,129 @@
+package nexus
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryDelayBackoffBounds(t *testing.T) {
+	policy := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for _, tc := range []struct {
+		attempt int
+		backoff time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{20, time.Second},
+	} {
+		for i := 0; i < 100; i++ {
+			if delay := policy.delay(tc.attempt, nil); delay < tc.backoff/2 || delay > tc.backoff {
+				t.Fatalf("Attempt %d: expected a delay between %s and %s, got %s", tc.attempt, tc.backoff/2, tc.backoff, delay)
+			}
+		}
+	}
+	if delay := (&RetryPolicy{}).delay(1, nil); delay != 0 {
+		t.Errorf("Expected no delay without a backoff, got %s", delay)
+	}
+}
+
+func TestRetryDelayRetryAfter(t *testing.T) {
+	for _, tc := range []struct {
+		retryAfter string
+		maxBackoff time.Duration
+		min, max   time.Duration
+	}{
+		{"2", 10 * time.Second, 2 * time.Second, 2 * time.Second},
+		{"3600", 10 * time.Second, 10 * time.Second, 10 * time.Second},
+		{"3600", 0, time.Hour, time.Hour},
+		{time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), 10 * time.Second, 10 * time.Second, 10 * time.Second},
+		// Values that cannot be used fall back to the backoff
+		{"0", 10 * time.Second, 50 * time.Millisecond, 100 * time.Millisecond},
+		{"-5", 10 * time.Second, 50 * time.Millisecond, 100 * time.Millisecond},
+		{"soon", 10 * time.Second, 50 * time.Millisecond, 100 * time.Millisecond},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 10 * time.Second, 50 * time.Millisecond, 100 * time.Millisecond},
+	} {
+		policy := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: tc.maxBackoff}
+		resp := &http.Response{Header: http.Header{"Retry-After": []string{tc.retryAfter}}}
+		if delay := policy.delay(1, resp); delay < tc.min || delay > tc.max {
+			t.Errorf("Retry-After %q with MaxBackoff %s: expected a delay between %s and %s, got %s",
+				tc.retryAfter, tc.maxBackoff, tc.min, tc.max, delay)
+		}
+	}
+}
+
+func TestRetryPolicyAllowsRequest(t *testing.T) {
+	replayable := withReplayable(context.Background())
+	for _, tc := range []struct {
+		name          string
+		ctx           context.Context
+		method        string
+		body          io.Reader
+		nonIdempotent bool
+		allowed       bool
+	}{
+		{"GET", context.Background(), "GET", nil, false, true},
+		{"DELETE", context.Background(), "DELETE", nil, false, true},
+		{"POST", context.Background(), "POST", bytes.NewReader(nil), false, false},
+		{"POST with RetryNonIdempotent", context.Background(), "POST", bytes.NewReader(nil), true, true},
+		{"replayable POST", replayable, "POST", bytes.NewReader(nil), false, true},
+		{"body that cannot be replayed", replayable, "PUT", ioutil.NopCloser(bytes.NewReader(nil)), true, false},
+	} {
+		req, err := http.NewRequestWithContext(tc.ctx, tc.method, "http://nexus", tc.body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		policy := &RetryPolicy{RetryNonIdempotent: tc.nonIdempotent}
+		if allowed := policy.allowsRequest(req); allowed != tc.allowed {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.allowed, allowed)
+		}
+	}
+}
+
+func TestRetrySend(t *testing.T) {
+	for _, tc := range []struct {
+		statuses []int
+		attempts int
+		status   int
+	}{
+		{[]int{503, 502, 200}, 3, 200},
+		{[]int{503, 503, 503, 503, 503}, 4, 503},
+		{[]int{500, 200}, 1, 500},
+		{[]int{429, 200}, 2, 200},
+	} {
+		requests := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tc.statuses[requests])
+			requests++
+		}))
+		policy := DefaultRetryPolicy()
+		policy.MinBackoff = time.Millisecond
+		policy.MaxBackoff = time.Millisecond
+		client, err := NewWithOptions(srv.URL, WithoutStatusCheck(), WithRetryPolicy(policy))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, err := client.NewRequest("GET", "service/rest/v1/status", nil, nil, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.send(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if requests != tc.attempts || resp.StatusCode != tc.status {
+			t.Errorf("Statuses %v: expected %d attempts ending in %d, got %d ending in %d",
+				tc.statuses, tc.attempts, tc.status, requests, resp.StatusCode)
+		}
+		srv.Close()
+	}
+}
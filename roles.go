@@ -0,0 +1,151 @@
+package nexus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Role represents a Nexus role. Privileges and Roles hold the names of the
+// privileges and the IDs of the nested roles it grants, so a role can be built
+// up from smaller roles.
+type Role struct {
+	ID          *string  `json:"id"`
+	Source      *string  `json:"source,omitempty"`
+	Name        *string  `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	Privileges  []string `json:"privileges"`
+	Roles       []string `json:"roles"`
+}
+
+// ListRolesInput provides an optional filter to ListRoles. Source limits the
+// results to the roles of a single source, such as UserSourceDefault for the roles
+// managed by Nexus or "LDAP" for the groups that can be mapped to roles.
+type ListRolesInput struct {
+	Source *string
+}
+
+// ListRoles returns the roles known to Nexus. The input may be nil to list all roles.
+func (n *Nexus) ListRoles(input *ListRolesInput) (res []*Role, err error) {
+	return n.ListRolesWithContext(context.Background(), input)
+}
+
+// ListRolesWithContext is the same as ListRoles with the addition of a context
+func (n *Nexus) ListRolesWithContext(ctx context.Context, input *ListRolesInput) (res []*Role, err error) {
+	res = make([]*Role, 0)
+	args := make(map[string]string)
+	if input != nil && input.Source != nil {
+		args["source"] = *input.Source
+	}
+	req, err := n.NewRequestWithContext(ctx, "GET", "service/rest/v1/security/roles", args, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: "Insufficient permissions to list roles",
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// GetRole retrieves a role from the default source by ID
+func (n *Nexus) GetRole(id string) (res *Role, err error) {
+	return n.GetRoleWithContext(context.Background(), id)
+}
+
+// GetRoleWithContext is the same as GetRole with the addition of a context
+func (n *Nexus) GetRoleWithContext(ctx context.Context, id string) (res *Role, err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/security/roles/%s", id)
+	req, err := n.NewRequestWithContext(ctx, "GET", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to get role %s", id),
+		404: fmt.Sprintf("Role %s does not exist", id),
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// CreateRole creates a new role in the default source. ID and Name are required.
+func (n *Nexus) CreateRole(role *Role) (res *Role, err error) {
+	return n.CreateRoleWithContext(context.Background(), role)
+}
+
+// CreateRoleWithContext is the same as CreateRole with the addition of a context
+func (n *Nexus) CreateRoleWithContext(ctx context.Context, role *Role) (res *Role, err error) {
+	if role.ID == nil || role.Name == nil {
+		err = errors.New("ID and Name are required for CreateRole")
+		return
+	}
+	payload, err := json.Marshal(role)
+	if err != nil {
+		return
+	}
+	req, err := n.NewRequestWithContext(ctx, "POST", "service/rest/v1/security/roles", nil, payload, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to create role %s", *role.ID),
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// UpdateRole replaces the name, description, privileges and nested roles of an existing role
+func (n *Nexus) UpdateRole(role *Role) (err error) {
+	return n.UpdateRoleWithContext(context.Background(), role)
+}
+
+// UpdateRoleWithContext is the same as UpdateRole with the addition of a context
+func (n *Nexus) UpdateRoleWithContext(ctx context.Context, role *Role) (err error) {
+	if role.ID == nil || role.Name == nil {
+		err = errors.New("ID and Name are required for UpdateRole")
+		return
+	}
+	payload, err := json.Marshal(role)
+	if err != nil {
+		return
+	}
+	endpoint := fmt.Sprintf("service/rest/v1/security/roles/%s", *role.ID)
+	req, err := n.NewRequestWithContext(ctx, "PUT", endpoint, nil, payload, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to update role %s", *role.ID),
+		404: fmt.Sprintf("Role %s does not exist", *role.ID),
+	}, false)
+	return
+}
+
+// DeleteRole removes the role with the given ID
+func (n *Nexus) DeleteRole(id string) (err error) {
+	return n.DeleteRoleWithContext(context.Background(), id)
+}
+
+// DeleteRoleWithContext is the same as DeleteRole with the addition of a context
+func (n *Nexus) DeleteRoleWithContext(ctx context.Context, id string) (err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/security/roles/%s", id)
+	req, err := n.NewRequestWithContext(ctx, "DELETE", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to delete role %s", id),
+		404: fmt.Sprintf("Role %s does not exist", id),
+	}, false)
+	return
+}
@@ -0,0 +1,142 @@
+package nexus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// RoutingRuleModeBlock and RoutingRuleModeAllow are used when specifying RoutingRule.Mode
+var (
+	RoutingRuleModeBlock = String("BLOCK")
+	RoutingRuleModeAllow = String("ALLOW")
+)
+
+// RoutingRule represents a routing rule that can be assigned to proxy repositories.
+// Matchers are regular expressions for request paths, which are either blocked or
+// are the only ones allowed depending on the Mode.
+type RoutingRule struct {
+	Name        *string  `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	Mode        *string  `json:"mode"`
+	Matchers    []string `json:"matchers"`
+}
+
+// ListRoutingRules returns the routing rules configured in Nexus
+func (n *Nexus) ListRoutingRules() (res []*RoutingRule, err error) {
+	return n.ListRoutingRulesWithContext(context.Background())
+}
+
+// ListRoutingRulesWithContext is the same as ListRoutingRules with the addition of a context
+func (n *Nexus) ListRoutingRulesWithContext(ctx context.Context) (res []*RoutingRule, err error) {
+	res = make([]*RoutingRule, 0)
+	req, err := n.NewRequestWithContext(ctx, "GET", "service/rest/v1/routing-rules", nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: "Insufficient permissions to list routing rules",
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// GetRoutingRule retrieves a routing rule by name
+func (n *Nexus) GetRoutingRule(name string) (res *RoutingRule, err error) {
+	return n.GetRoutingRuleWithContext(context.Background(), name)
+}
+
+// GetRoutingRuleWithContext is the same as GetRoutingRule with the addition of a context
+func (n *Nexus) GetRoutingRuleWithContext(ctx context.Context, name string) (res *RoutingRule, err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/routing-rules/%s", name)
+	req, err := n.NewRequestWithContext(ctx, "GET", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to get routing rule %s", name),
+		404: fmt.Sprintf("Routing rule %s does not exist", name),
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// CreateRoutingRule creates a new routing rule. Name, Mode and Matchers are required.
+func (n *Nexus) CreateRoutingRule(rule *RoutingRule) (err error) {
+	return n.CreateRoutingRuleWithContext(context.Background(), rule)
+}
+
+// CreateRoutingRuleWithContext is the same as CreateRoutingRule with the addition of a context
+func (n *Nexus) CreateRoutingRuleWithContext(ctx context.Context, rule *RoutingRule) (err error) {
+	if rule.Name == nil || rule.Mode == nil {
+		err = errors.New("Name and Mode are required for CreateRoutingRule")
+		return
+	}
+	payload, err := json.Marshal(rule)
+	if err != nil {
+		return
+	}
+	req, err := n.NewRequestWithContext(ctx, "POST", "service/rest/v1/routing-rules", nil, payload, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to create routing rule %s", *rule.Name),
+	}, false)
+	return
+}
+
+// UpdateRoutingRule replaces the mode and matchers of an existing routing rule
+func (n *Nexus) UpdateRoutingRule(rule *RoutingRule) (err error) {
+	return n.UpdateRoutingRuleWithContext(context.Background(), rule)
+}
+
+// UpdateRoutingRuleWithContext is the same as UpdateRoutingRule with the addition of a context
+func (n *Nexus) UpdateRoutingRuleWithContext(ctx context.Context, rule *RoutingRule) (err error) {
+	if rule.Name == nil || rule.Mode == nil {
+		err = errors.New("Name and Mode are required for UpdateRoutingRule")
+		return
+	}
+	payload, err := json.Marshal(rule)
+	if err != nil {
+		return
+	}
+	endpoint := fmt.Sprintf("service/rest/v1/routing-rules/%s", *rule.Name)
+	req, err := n.NewRequestWithContext(ctx, "PUT", endpoint, nil, payload, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to update routing rule %s", *rule.Name),
+		404: fmt.Sprintf("Routing rule %s does not exist", *rule.Name),
+	}, false)
+	return
+}
+
+// DeleteRoutingRule removes the routing rule with the given name. Nexus refuses
+// to delete rules that are still assigned to repositories.
+func (n *Nexus) DeleteRoutingRule(name string) (err error) {
+	return n.DeleteRoutingRuleWithContext(context.Background(), name)
+}
+
+// DeleteRoutingRuleWithContext is the same as DeleteRoutingRule with the addition of a context
+func (n *Nexus) DeleteRoutingRuleWithContext(ctx context.Context, name string) (err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/routing-rules/%s", name)
+	req, err := n.NewRequestWithContext(ctx, "DELETE", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		400: fmt.Sprintf("Routing rule %s is in use by one or more repositories", name),
+		403: fmt.Sprintf("Insufficient permissions to delete routing rule %s", name),
+		404: fmt.Sprintf("Routing rule %s does not exist", name),
+	}, false)
+	return
+}
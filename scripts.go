@@ -1,6 +1,7 @@
 package nexus
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -109,7 +110,13 @@ func (n *Nexus) NewEphemeralScript(script *Script) (boundScript *EphemeralScript
 
 // Execute creates, executes, and then destroys an ephemeral script.
 func (s *EphemeralScript) Execute(args interface{}) (res *ExecuteScriptResponse, err error) {
-	script, err := s.Script.client.CreateScript(&Script{
+	return s.ExecuteWithContext(context.Background(), args)
+}
+
+// ExecuteWithContext is the same as Execute with the addition of a context.
+// The script is removed from Nexus even when the context is cancelled during execution.
+func (s *EphemeralScript) ExecuteWithContext(ctx context.Context, args interface{}) (res *ExecuteScriptResponse, err error) {
+	script, err := s.Script.client.CreateScriptWithContext(ctx, &Script{
 		Name:    String(uuid.New().String()),
 		Type:    ScriptTypeGroovy,
 		Content: s.Script.Content,
@@ -118,7 +125,7 @@ func (s *EphemeralScript) Execute(args interface{}) (res *ExecuteScriptResponse,
 		return
 	}
 	defer script.Delete()
-	res, err = script.Execute(args)
+	res, err = script.ExecuteWithContext(ctx, args)
 	return
 }
 
@@ -135,32 +142,42 @@ type ExecuteScriptResponse struct {
 
 // Execute this script instance
 func (s *Script) Execute(args interface{}) (res *ExecuteScriptResponse, err error) {
-	res, err = s.client.ExecuteScript(*s.Name, args)
+	return s.ExecuteWithContext(context.Background(), args)
+}
+
+// ExecuteWithContext is the same as Execute with the addition of a context
+func (s *Script) ExecuteWithContext(ctx context.Context, args interface{}) (res *ExecuteScriptResponse, err error) {
+	res, err = s.client.ExecuteScriptWithContext(ctx, *s.Name, args)
 	return
 }
 
 // Delete this script instance
 func (s *Script) Delete() (err error) {
-	err = s.client.DeleteScript(*s.Name)
+	return s.DeleteWithContext(context.Background())
+}
+
+// DeleteWithContext is the same as Delete with the addition of a context
+func (s *Script) DeleteWithContext(ctx context.Context) (err error) {
+	err = s.client.DeleteScriptWithContext(ctx, *s.Name)
 	return
 }
 
 // ensureAndExecute is used internally for the process of ensuring the contents
 // of a script and subsequently executing it.
-func (s *Script) ensureAndExecute(args interface{}) (res *ExecuteScriptResponse, err error) {
-	script, err := s.client.GetScript(*s.Name)
-	if err != nil {
-		script, err = s.client.CreateScript(&Script{
+func (s *Script) ensureAndExecute(ctx context.Context, args interface{}) (res *ExecuteScriptResponse, err error) {
+	script, err := s.client.GetScriptWithContext(ctx, *s.Name)
+	if errors.Is(err, ErrNotFound) {
+		script, err = s.client.CreateScriptWithContext(ctx, &Script{
 			Name:    s.Name,
 			Type:    s.Type,
 			Content: s.Content,
 		})
-		if err != nil {
-			return
-		}
+	}
+	if err != nil {
+		return
 	}
 	if *script.Content != *s.Content {
-		script, err = s.client.UpdateScript(&Script{
+		script, err = s.client.UpdateScriptWithContext(ctx, &Script{
 			Name:    s.Name,
 			Type:    s.Type,
 			Content: s.Content,
@@ -169,14 +186,19 @@ func (s *Script) ensureAndExecute(args interface{}) (res *ExecuteScriptResponse,
 			return
 		}
 	}
-	res, err = script.Execute(args)
+	res, err = script.ExecuteWithContext(ctx, args)
 	return
 }
 
 // GetScript returns an executable script instance by name
 func (n *Nexus) GetScript(name string) (script *Script, err error) {
+	return n.GetScriptWithContext(context.Background(), name)
+}
+
+// GetScriptWithContext is the same as GetScript with the addition of a context
+func (n *Nexus) GetScriptWithContext(ctx context.Context, name string) (script *Script, err error) {
 	url := fmt.Sprintf("service/rest/v1/script/%s", name)
-	req, err := n.NewRequest("GET", url, nil, nil, "")
+	req, err := n.NewRequestWithContext(ctx, "GET", url, nil, nil, "")
 	if err != nil {
 		return
 	}
@@ -206,22 +228,33 @@ func marshalScriptArgs(args interface{}) (payload []byte, err error) {
 // ExecuteScript executes the script with the given name and returns the result.
 // Args must be a structure that can be marshaled to JSON or nil.
 func (n *Nexus) ExecuteScript(name string, args interface{}) (res *ExecuteScriptResponse, err error) {
+	return n.ExecuteScriptWithContext(context.Background(), name, args)
+}
+
+// ExecuteScriptWithContext is the same as ExecuteScript with the addition of a context
+func (n *Nexus) ExecuteScriptWithContext(ctx context.Context, name string, args interface{}) (res *ExecuteScriptResponse, err error) {
 	payload, err := marshalScriptArgs(args)
 	if err != nil {
 		return
 	}
 	url := fmt.Sprintf("service/rest/v1/script/%s/run", name)
-	req, err := n.NewRequest("POST", url, nil, payload, "text/plain")
+	req, err := n.NewRequestWithContext(ctx, "POST", url, nil, payload, "text/plain")
 	if err != nil {
 		return
 	}
-	body, err := n.Do(req, nil, true)
+	body, err := n.Do(req, map[int]string{
+		404: fmt.Sprintf("Script %s does not exist", name),
+	}, false)
 	if err != nil {
-		err = json.Unmarshal([]byte(err.Error()), &res)
-		if err != nil {
-			return
+		// Exceptions raised by the script are returned as a JSON body
+		// containing the text of the exception in the result.
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode != 404 {
+			var failed *ExecuteScriptResponse
+			if json.Unmarshal([]byte(apiErr.Body), &failed) == nil && failed != nil && failed.Result != nil {
+				apiErr.Message = *failed.Result
+			}
 		}
-		err = errors.New(*res.Result)
 		return
 	}
 	err = json.Unmarshal(body, &res)
@@ -233,8 +266,13 @@ func (n *Nexus) ExecuteScript(name string, args interface{}) (res *ExecuteScript
 
 // DeleteScript deletes a script with a given name
 func (n *Nexus) DeleteScript(name string) (err error) {
+	return n.DeleteScriptWithContext(context.Background(), name)
+}
+
+// DeleteScriptWithContext is the same as DeleteScript with the addition of a context
+func (n *Nexus) DeleteScriptWithContext(ctx context.Context, name string) (err error) {
 	url := fmt.Sprintf("service/rest/v1/script/%s", name)
-	req, err := n.NewRequest("DELETE", url, nil, nil, "")
+	req, err := n.NewRequestWithContext(ctx, "DELETE", url, nil, nil, "")
 	if err != nil {
 		return
 	}
@@ -246,8 +284,13 @@ func (n *Nexus) DeleteScript(name string) (err error) {
 
 // ListScripts returns all script objects on the Nexus host
 func (n *Nexus) ListScripts() (res *ListScriptsResponse, err error) {
+	return n.ListScriptsWithContext(context.Background())
+}
+
+// ListScriptsWithContext is the same as ListScripts with the addition of a context
+func (n *Nexus) ListScriptsWithContext(ctx context.Context) (res *ListScriptsResponse, err error) {
 	res = &ListScriptsResponse{}
-	req, err := n.NewRequest("GET", "service/rest/v1/script", nil, nil, "")
+	req, err := n.NewRequestWithContext(ctx, "GET", "service/rest/v1/script", nil, nil, "")
 	if err != nil {
 		return
 	}
@@ -268,6 +311,11 @@ func (n *Nexus) ListScripts() (res *ListScriptsResponse, err error) {
 // CreateScript creates a new script with the given parameters and returns
 // a copy of the provided instance with the bound client so Execute() can be called on it.
 func (n *Nexus) CreateScript(script *Script) (boundScript *Script, err error) {
+	return n.CreateScriptWithContext(context.Background(), script)
+}
+
+// CreateScriptWithContext is the same as CreateScript with the addition of a context
+func (n *Nexus) CreateScriptWithContext(ctx context.Context, script *Script) (boundScript *Script, err error) {
 	if script.Name == nil || script.Content == nil {
 		err = errors.New("Script instance must contain a name and content")
 		return
@@ -276,7 +324,7 @@ func (n *Nexus) CreateScript(script *Script) (boundScript *Script, err error) {
 	if err != nil {
 		return
 	}
-	req, err := n.NewRequest("POST", "service/rest/v1/script", nil, payload, "")
+	req, err := n.NewRequestWithContext(ctx, "POST", "service/rest/v1/script", nil, payload, "")
 	if err != nil {
 		return
 	}
@@ -284,6 +332,11 @@ func (n *Nexus) CreateScript(script *Script) (boundScript *Script, err error) {
 		500: fmt.Sprintf("Script with name %s already exists, use UpdateScript instead", *script.Name),
 	}, false)
 	if err != nil {
+		// Nexus reports duplicate script names as an internal server error
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 500 {
+			apiErr.kind = ErrConflict
+		}
 		return
 	}
 	boundScript = &Script{
@@ -298,6 +351,11 @@ func (n *Nexus) CreateScript(script *Script) (boundScript *Script, err error) {
 // UpdateScript takes the given script instance and ensures it's counterpart on Nexus
 // by the same name has the same contents.
 func (n *Nexus) UpdateScript(script *Script) (boundScript *Script, err error) {
+	return n.UpdateScriptWithContext(context.Background(), script)
+}
+
+// UpdateScriptWithContext is the same as UpdateScript with the addition of a context
+func (n *Nexus) UpdateScriptWithContext(ctx context.Context, script *Script) (boundScript *Script, err error) {
 	if script.Name == nil || script.Content == nil {
 		err = errors.New("Script instance must contain a name and content")
 		return
@@ -307,7 +365,7 @@ func (n *Nexus) UpdateScript(script *Script) (boundScript *Script, err error) {
 		return
 	}
 	url := fmt.Sprintf("service/rest/v1/script/%s", *script.Name)
-	req, err := n.NewRequest("PUT", url, nil, payload, "")
+	req, err := n.NewRequestWithContext(ctx, "PUT", url, nil, payload, "")
 	if err != nil {
 		return
 	}
@@ -0,0 +1,397 @@
+package nexus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SearchSortGroup and friends are the values accepted by SearchInput.Sort
+var (
+	SearchSortGroup      = String("group")
+	SearchSortName       = String("name")
+	SearchSortVersion    = String("version")
+	SearchSortRepository = String("repository")
+)
+
+// SearchDirectionAsc and SearchDirectionDesc are the values accepted by SearchInput.Direction
+var (
+	SearchDirectionAsc  = String("asc")
+	SearchDirectionDesc = String("desc")
+)
+
+// SearchInput is used to provide parameters to SearchComponents and SearchAssets.
+// Every field is optional and fields that are set are combined, so a search
+// only returns items matching all of them. Format-specific parameters that are
+// not covered by a field can be provided in Parameters using their query name,
+// e.g. "npm.author".
+type SearchInput struct {
+	// Keyword is a free text search across all fields
+	Keyword    *string
+	Repository *string
+	Format     *string
+	Group      *string
+	Name       *string
+	Version    *string
+	Prerelease *bool
+	// Sort is one of SearchSortGroup, SearchSortName, SearchSortVersion or SearchSortRepository
+	Sort *string
+	// Direction is one of SearchDirectionAsc or SearchDirectionDesc
+	Direction *string
+
+	MD5    *string
+	SHA1   *string
+	SHA256 *string
+	SHA512 *string
+
+	MavenGroupID     *string
+	MavenArtifactID  *string
+	MavenBaseVersion *string
+	MavenExtension   *string
+	MavenClassifier  *string
+
+	NpmScope *string
+
+	DockerImageName     *string
+	DockerImageTag      *string
+	DockerLayerID       *string
+	DockerContentDigest *string
+
+	NugetID   *string
+	NugetTags *string
+
+	PypiClassifiers *string
+	PypiDescription *string
+	PypiKeywords    *string
+	PypiSummary     *string
+
+	RubygemsDescription *string
+	RubygemsPlatform    *string
+	RubygemsSummary     *string
+
+	YumArchitecture *string
+	YumName         *string
+
+	Parameters *map[string]string
+
+	ContinuationToken *string
+}
+
+// SearchComponentsResponse is a response from a SearchComponents call
+type SearchComponentsResponse struct {
+	Items             []*Component `json:"items"`
+	ContinuationToken *string      `json:"continuationToken"`
+}
+
+// SearchAssetsResponse is a response from a SearchAssets call
+type SearchAssetsResponse struct {
+	Items             []*Asset `json:"items"`
+	ContinuationToken *string  `json:"continuationToken"`
+}
+
+// args returns the query parameters for the search
+func (input *SearchInput) args() map[string]string {
+	args := make(map[string]string)
+	params := map[string]*string{
+		"q":                    input.Keyword,
+		"repository":           input.Repository,
+		"format":               input.Format,
+		"group":                input.Group,
+		"name":                 input.Name,
+		"version":              input.Version,
+		"sort":                 input.Sort,
+		"direction":            input.Direction,
+		"md5":                  input.MD5,
+		"sha1":                 input.SHA1,
+		"sha256":               input.SHA256,
+		"sha512":               input.SHA512,
+		"maven.groupId":        input.MavenGroupID,
+		"maven.artifactId":     input.MavenArtifactID,
+		"maven.baseVersion":    input.MavenBaseVersion,
+		"maven.extension":      input.MavenExtension,
+		"maven.classifier":     input.MavenClassifier,
+		"npm.scope":            input.NpmScope,
+		"docker.imageName":     input.DockerImageName,
+		"docker.imageTag":      input.DockerImageTag,
+		"docker.layerId":       input.DockerLayerID,
+		"docker.contentDigest": input.DockerContentDigest,
+		"nuget.id":             input.NugetID,
+		"nuget.tags":           input.NugetTags,
+		"pypi.classifiers":     input.PypiClassifiers,
+		"pypi.description":     input.PypiDescription,
+		"pypi.keywords":        input.PypiKeywords,
+		"pypi.summary":         input.PypiSummary,
+		"rubygems.description": input.RubygemsDescription,
+		"rubygems.platform":    input.RubygemsPlatform,
+		"rubygems.summary":     input.RubygemsSummary,
+		"yum.architecture":     input.YumArchitecture,
+		"yum.name":             input.YumName,
+		"continuationToken":    input.ContinuationToken,
+	}
+	for k, v := range params {
+		if v != nil {
+			args[k] = *v
+		}
+	}
+	if input.Prerelease != nil {
+		args["prerelease"] = strconv.FormatBool(*input.Prerelease)
+	}
+	if input.Parameters != nil {
+		for k, v := range *input.Parameters {
+			args[k] = v
+		}
+	}
+	return args
+}
+
+func (n *Nexus) newSearchReq(ctx context.Context, endpoint string, input *SearchInput) (req *http.Request, err error) {
+	req, err = n.NewRequestWithContext(ctx, "GET", endpoint, input.args(), nil, "")
+	return
+}
+
+// SearchComponents returns a page of components matching the given search and
+// a token to request the next page.
+//
+// Example
+//
+// Find every 1.x release of a Maven artifact
+//
+//     res, err := client.SearchComponents(&nexus.SearchInput{
+//         Repository:      nexus.String("maven-releases"),
+//         MavenGroupID:    nexus.String("com.example"),
+//         MavenArtifactID: nexus.String("my-app"),
+//         Version:         nexus.String("1.*"),
+//     })
+func (n *Nexus) SearchComponents(input *SearchInput) (res *SearchComponentsResponse, err error) {
+	return n.SearchComponentsWithContext(context.Background(), input)
+}
+
+// SearchComponentsWithContext is the same as SearchComponents with the addition of a context
+func (n *Nexus) SearchComponentsWithContext(ctx context.Context, input *SearchInput) (res *SearchComponentsResponse, err error) {
+	req, err := n.newSearchReq(ctx, "service/rest/v1/search", input)
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: "Insufficient permissions to search components",
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return
+	}
+	for _, x := range res.Items {
+		x.client = n
+		for _, y := range x.Assets {
+			y.client = n
+		}
+	}
+	return
+}
+
+// SearchComponentsPages is identical in usage to ListAssetsPages
+func (n *Nexus) SearchComponentsPages(input *SearchInput, cb func(res *SearchComponentsResponse, last bool) (cont bool, err error)) error {
+	return n.SearchComponentsPagesWithContext(context.Background(), input, cb)
+}
+
+// SearchComponentsPagesWithContext is identical in usage to ListAssetsPagesWithContext
+func (n *Nexus) SearchComponentsPagesWithContext(ctx context.Context, input *SearchInput, cb func(res *SearchComponentsResponse, last bool) (cont bool, err error)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	res, err := n.SearchComponentsWithContext(ctx, input)
+	if err != nil {
+		return err
+	}
+	if res.ContinuationToken == nil {
+		_, err = cb(res, true)
+		return err
+	}
+	cont, err := cb(res, false)
+	if err != nil {
+		return err
+	}
+	if !cont {
+		return nil
+	}
+	newInput := *input
+	newInput.ContinuationToken = res.ContinuationToken
+	return n.SearchComponentsPagesWithContext(ctx, &newInput, cb)
+}
+
+// SearchAssets returns a page of assets matching the given search and a token
+// to request the next page.
+func (n *Nexus) SearchAssets(input *SearchInput) (res *SearchAssetsResponse, err error) {
+	return n.SearchAssetsWithContext(context.Background(), input)
+}
+
+// SearchAssetsWithContext is the same as SearchAssets with the addition of a context
+func (n *Nexus) SearchAssetsWithContext(ctx context.Context, input *SearchInput) (res *SearchAssetsResponse, err error) {
+	req, err := n.newSearchReq(ctx, "service/rest/v1/search/assets", input)
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: "Insufficient permissions to search assets",
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return
+	}
+	for _, x := range res.Items {
+		x.client = n
+	}
+	return
+}
+
+// SearchAssetsPages is identical in usage to ListAssetsPages
+func (n *Nexus) SearchAssetsPages(input *SearchInput, cb func(res *SearchAssetsResponse, last bool) (cont bool, err error)) error {
+	return n.SearchAssetsPagesWithContext(context.Background(), input, cb)
+}
+
+// SearchAssetsPagesWithContext is identical in usage to ListAssetsPagesWithContext
+func (n *Nexus) SearchAssetsPagesWithContext(ctx context.Context, input *SearchInput, cb func(res *SearchAssetsResponse, last bool) (cont bool, err error)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	res, err := n.SearchAssetsWithContext(ctx, input)
+	if err != nil {
+		return err
+	}
+	if res.ContinuationToken == nil {
+		_, err = cb(res, true)
+		return err
+	}
+	cont, err := cb(res, false)
+	if err != nil {
+		return err
+	}
+	if !cont {
+		return nil
+	}
+	newInput := *input
+	newInput.ContinuationToken = res.ContinuationToken
+	return n.SearchAssetsPagesWithContext(ctx, &newInput, cb)
+}
+
+// etagSHA1Pattern matches the ETag Nexus sets on assets, "{SHA1{<sha1>}}", as well
+// as a quoted bare sha1 of the content.
+var etagSHA1Pattern = regexp.MustCompile(`^"(?:\{SHA1\{([0-9a-fA-F]{40})\}\}|([0-9a-fA-F]{40}))"$`)
+
+// etagSHA1 returns the sha1 of the content reported in an ETag, or an empty string
+// when the ETag does not contain one.
+func etagSHA1(etag string) string {
+	match := etagSHA1Pattern.FindStringSubmatch(etag)
+	if match == nil {
+		return ""
+	}
+	if match[1] != "" {
+		return match[1]
+	}
+	return match[2]
+}
+
+// DownloadSearchedAsset resolves the search to a single asset and streams it to the
+// given writer, returning the number of bytes written. Unless Sort is provided, the
+// search must match exactly one asset. An error matching ErrNotFound is returned when
+// nothing matches, and one matching ErrMultipleAssets when several do. When Sort is
+// provided, the first asset in the sorted results is downloaded.
+//
+// When Nexus reports the sha1 of the asset in the ETag header, the content is
+// verified and a *ChecksumError is returned on a mismatch.
+//
+// Example
+//
+// Download a specific Maven artifact
+//
+//     file, _ := os.Create("my-app.jar")
+//     defer file.Close()
+//     _, err := client.DownloadSearchedAsset(&nexus.SearchInput{
+//         Repository:       nexus.String("maven-releases"),
+//         MavenGroupID:     nexus.String("com.example"),
+//         MavenArtifactID:  nexus.String("my-app"),
+//         MavenBaseVersion: nexus.String("1.2.0"),
+//         MavenExtension:   nexus.String("jar"),
+//     }, file)
+func (n *Nexus) DownloadSearchedAsset(input *SearchInput, w io.Writer) (written int64, err error) {
+	return n.DownloadSearchedAssetWithContext(context.Background(), input, w)
+}
+
+// DownloadSearchedAssetWithContext is the same as DownloadSearchedAsset with the addition of a context
+func (n *Nexus) DownloadSearchedAssetWithContext(ctx context.Context, input *SearchInput, w io.Writer) (written int64, err error) {
+	req, err := n.newSearchReq(ctx, "service/rest/v1/search/assets/download", input)
+	if err != nil {
+		return
+	}
+	resp, err := n.DoStream(req, map[int]string{
+		403: "Insufficient permissions to download the asset",
+		404: "No assets matched the search",
+	})
+	if err != nil {
+		// Other invalid searches are also rejected with a 400, so their message is
+		// left to the body returned by Nexus
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 400 && strings.Contains(strings.ToLower(apiErr.Body), "multiple") {
+			apiErr.Message = "The search matched more than one asset, refine it or provide a Sort to download the first result"
+			apiErr.kind = ErrMultipleAssets
+		}
+		return
+	}
+	defer resp.Body.Close()
+	var dest io.Writer = w
+	var verifier *checksumVerifier
+	if sha1 := etagSHA1(resp.Header.Get("ETag")); sha1 != "" {
+		verifier = newChecksumVerifier(&Asset{
+			Path:     String(resp.Request.URL.Path),
+			Checksum: &map[string]string{"sha1": sha1},
+		})
+		dest = io.MultiWriter(w, verifier)
+	}
+	written, err = io.Copy(dest, resp.Body)
+	if err == nil && verifier != nil {
+		err = verifier.Verify()
+	}
+	return
+}
+
+// DownloadLatest is the same as DownloadSearchedAsset, except the results are
+// sorted by version in descending order unless another Sort or Direction is given,
+// so the latest version matching the search is downloaded.
+//
+// Example
+//
+// Download the latest release of a Maven artifact with a classifier
+//
+//     _, err := client.DownloadLatest(&nexus.SearchInput{
+//         Repository:      nexus.String("maven-releases"),
+//         MavenGroupID:    nexus.String("com.example"),
+//         MavenArtifactID: nexus.String("my-app"),
+//         MavenExtension:  nexus.String("tar.gz"),
+//         MavenClassifier: nexus.String("dist"),
+//         Prerelease:      nexus.Bool(false),
+//     }, file)
+func (n *Nexus) DownloadLatest(input *SearchInput, w io.Writer) (written int64, err error) {
+	return n.DownloadLatestWithContext(context.Background(), input, w)
+}
+
+// DownloadLatestWithContext is the same as DownloadLatest with the addition of a context
+func (n *Nexus) DownloadLatestWithContext(ctx context.Context, input *SearchInput, w io.Writer) (written int64, err error) {
+	sorted := *input
+	if sorted.Sort == nil {
+		sorted.Sort = SearchSortVersion
+	}
+	if sorted.Direction == nil {
+		sorted.Direction = SearchDirectionDesc
+	}
+	return n.DownloadSearchedAssetWithContext(ctx, &sorted, w)
+}
@@ -0,0 +1,101 @@
+package nexus
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// helloSHA1 is the sha1 of "hello"
+const helloSHA1 = "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d"
+
+func TestETagSHA1(t *testing.T) {
+	for _, tc := range []struct {
+		etag     string
+		expected string
+	}{
+		{`"{SHA1{` + helloSHA1 + `}}"`, helloSHA1},
+		{`"` + helloSHA1 + `"`, helloSHA1},
+		{`"{SHA1{AAF4C61DDCC5E8A2DABEDE0F3B482CD9AEA9434D}}"`, "AAF4C61DDCC5E8A2DABEDE0F3B482CD9AEA9434D"},
+		{`"{SHA1{` + helloSHA1[:39] + `}}"`, ""},
+		{`"{` + helloSHA1 + `}"`, ""},
+		{`W/"` + helloSHA1 + `"`, ""},
+		{helloSHA1, ""},
+		{"", ""},
+	} {
+		if actual := etagSHA1(tc.etag); actual != tc.expected {
+			t.Errorf("etagSHA1(%s): expected %q, got %q", tc.etag, tc.expected, actual)
+		}
+	}
+}
+
+func newSearchDownloadServer(etag string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("name") {
+		case "multiple":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("Search returned multiple assets, please refine search criteria to find a single asset or use the sort query parameter to retrieve the first result."))
+		case "invalid":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("Invalid sort parameter"))
+		default:
+			w.Header().Set("ETag", etag)
+			w.Write([]byte("hello"))
+		}
+	}))
+}
+
+func TestDownloadSearchedAssetVerifiesETag(t *testing.T) {
+	for _, tc := range []struct {
+		etag     string
+		mismatch bool
+	}{
+		{`"{SHA1{` + helloSHA1 + `}}"`, false},
+		{`"{SHA1{0000000000000000000000000000000000000000}}"`, true},
+		{`"0000000000000000000000000000000000000000"`, true},
+		{`"not-a-checksum"`, false},
+	} {
+		srv := newSearchDownloadServer(tc.etag)
+		client, err := NewWithOptions(srv.URL, WithoutStatusCheck())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		_, err = client.DownloadSearchedAsset(&SearchInput{Name: String("hello")}, &buf)
+		var checksumErr *ChecksumError
+		if tc.mismatch && !errors.As(err, &checksumErr) {
+			t.Errorf("ETag %s: expected a *ChecksumError, got %v", tc.etag, err)
+		}
+		if !tc.mismatch && err != nil {
+			t.Errorf("ETag %s: unexpected error %v", tc.etag, err)
+		}
+		if buf.String() != "hello" {
+			t.Errorf("ETag %s: unexpected content %q", tc.etag, buf.String())
+		}
+		srv.Close()
+	}
+}
+
+func TestDownloadSearchedAssetBadRequest(t *testing.T) {
+	srv := newSearchDownloadServer("")
+	defer srv.Close()
+	client, err := NewWithOptions(srv.URL, WithoutStatusCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	_, err = client.DownloadSearchedAsset(&SearchInput{Name: String("multiple")}, &buf)
+	if !errors.Is(err, ErrMultipleAssets) {
+		t.Errorf("Expected ErrMultipleAssets, got %v", err)
+	}
+	_, err = client.DownloadSearchedAsset(&SearchInput{Name: String("invalid")}, &buf)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Expected an APIError with status 400, got %v", err)
+	}
+	if errors.Is(err, ErrMultipleAssets) || apiErr.Message != "" {
+		t.Errorf("Expected the error to keep the body from Nexus, got %v", err)
+	}
+}
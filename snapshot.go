@@ -0,0 +1,471 @@
+package nexus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// internalScriptPrefix is the prefix of the scripts this library installs for
+// its own use, which are excluded from snapshots.
+const internalScriptPrefix = "nexus3-go-"
+
+// Snapshot is a point-in-time copy of the configuration of a Nexus instance
+// that can be imported into another instance. The repositories, blob stores and
+// cleanup policies are stored as a DesiredState. Only the privileges created by
+// users are included, since Nexus creates the others itself. Users never include passwords.
+type Snapshot struct {
+	DesiredState
+
+	Scripts          []*Script          `json:"scripts,omitempty"`
+	ContentSelectors []*ContentSelector `json:"contentSelectors,omitempty"`
+	RoutingRules     []*RoutingRule     `json:"routingRules,omitempty"`
+	Privileges       []*Privilege       `json:"privileges,omitempty"`
+	Roles            []*Role            `json:"roles,omitempty"`
+	Users            []*User            `json:"users,omitempty"`
+}
+
+// ImportInput provides parameters to Import. UserPassword is the initial password
+// given to local users that do not exist yet, since snapshots do not contain passwords.
+// When DryRun is true Import returns the plan without applying it.
+type ImportInput struct {
+	Snapshot     *Snapshot
+	UserPassword *string
+	DryRun       *bool
+}
+
+// Export reads the configuration of the Nexus instance into a Snapshot. Only
+// roles from the default source are included. Scripts are skipped when scripting
+// is disabled on the server, as are cleanup policies on versions of Nexus without
+// the cleanup policy API. Without the blob store REST API the path of file blob
+// stores is not available, so it is assumed to be the default path named after the store.
+func (n *Nexus) Export() (snapshot *Snapshot, err error) {
+	return n.ExportWithContext(context.Background())
+}
+
+// ExportWithContext is the same as Export with the addition of a context
+func (n *Nexus) ExportWithContext(ctx context.Context) (snapshot *Snapshot, err error) {
+	snapshot = &Snapshot{}
+	if err = n.exportBlobStores(ctx, snapshot); err != nil {
+		return
+	}
+	if snapshot.CleanupPolicies, err = n.ListCleanupPoliciesWithContext(ctx); errors.Is(err, ErrNotFound) {
+		snapshot.CleanupPolicies, err = nil, nil
+	}
+	if err != nil {
+		return
+	}
+	if err = n.exportRepositories(ctx, snapshot); err != nil {
+		return
+	}
+	if err = n.exportScripts(ctx, snapshot); err != nil {
+		return
+	}
+	if snapshot.ContentSelectors, err = n.ListContentSelectorsWithContext(ctx); err != nil {
+		return
+	}
+	if snapshot.RoutingRules, err = n.ListRoutingRulesWithContext(ctx); err != nil {
+		return
+	}
+	if err = n.exportPrivileges(ctx, snapshot); err != nil {
+		return
+	}
+	if snapshot.Roles, err = n.ListRolesWithContext(ctx, &ListRolesInput{Source: UserSourceDefault}); err != nil {
+		return
+	}
+	if snapshot.Users, err = n.ListUsersWithContext(ctx, nil); err != nil {
+		return
+	}
+	for _, user := range snapshot.Users {
+		user.Password = nil
+	}
+	return
+}
+
+func (n *Nexus) exportBlobStores(ctx context.Context, snapshot *Snapshot) (err error) {
+	stores, err := n.ListBlobStoresWithContext(ctx)
+	if err != nil {
+		return
+	}
+	rest, err := n.blobStoreRESTAvailable(ctx)
+	if err != nil {
+		return
+	}
+	for _, store := range stores {
+		if store.Config == nil || store.Config.Name == nil {
+			continue
+		}
+		if rest {
+			if store, err = n.GetBlobStoreWithContext(ctx, *store.Config.Name); err != nil {
+				return
+			}
+		}
+		input := store.createInput()
+		if input.Path == nil && input.Type != nil && strings.EqualFold(*input.Type, *BlobStoreTypeFile) {
+			input.Path = input.Name
+		}
+		snapshot.BlobStores = append(snapshot.BlobStores, input)
+	}
+	return
+}
+
+func (n *Nexus) exportRepositories(ctx context.Context, snapshot *Snapshot) (err error) {
+	repos, err := n.ListRepositoriesWithContext(ctx)
+	if err != nil {
+		return
+	}
+	for _, summary := range repos {
+		if summary.Name == nil || summary.Type == nil {
+			continue
+		}
+		var repo *Repository
+		if repo, err = n.GetRepositoryWithContext(ctx, *summary.Name); err != nil {
+			return
+		}
+		var value map[string]interface{}
+		if value, err = repositoryInputValue(repo); err != nil {
+			return
+		}
+		var body []byte
+		if body, err = json.Marshal(value); err != nil {
+			return
+		}
+		switch *summary.Type {
+		case "hosted":
+			input := &HostedRepositoryInput{}
+			if err = json.Unmarshal(body, input); err != nil {
+				return
+			}
+			snapshot.HostedRepositories = append(snapshot.HostedRepositories, input)
+		case "proxy":
+			input := &ProxyRepositoryInput{}
+			if err = json.Unmarshal(body, input); err != nil {
+				return
+			}
+			snapshot.ProxyRepositories = append(snapshot.ProxyRepositories, input)
+		case "group":
+			input := &GroupRepositoryInput{}
+			if err = json.Unmarshal(body, input); err != nil {
+				return
+			}
+			snapshot.GroupRepositories = append(snapshot.GroupRepositories, input)
+		}
+	}
+	return
+}
+
+func (n *Nexus) exportPrivileges(ctx context.Context, snapshot *Snapshot) (err error) {
+	privileges, err := n.ListPrivilegesWithContext(ctx)
+	if err != nil {
+		return
+	}
+	for _, privilege := range privileges {
+		if privilege.ReadOnly == nil || !*privilege.ReadOnly {
+			snapshot.Privileges = append(snapshot.Privileges, privilege)
+		}
+	}
+	return
+}
+
+func (n *Nexus) exportScripts(ctx context.Context, snapshot *Snapshot) (err error) {
+	res, err := n.ListScriptsWithContext(ctx)
+	if err != nil {
+		// Nexus responds with 410 Gone when scripting is disabled
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 410 {
+			err = nil
+		}
+		return
+	}
+	for _, script := range res.Scripts {
+		if script.Name != nil && !strings.HasPrefix(*script.Name, internalScriptPrefix) {
+			snapshot.Scripts = append(snapshot.Scripts, script)
+		}
+	}
+	return
+}
+
+// Import applies a Snapshot to the Nexus instance and returns the plan that was
+// applied. Objects in the snapshot are created when missing and updated when they
+// differ, while objects that are not in the snapshot are left alone. Users from
+// external sources such as LDAP are only updated, since they cannot be created.
+//
+// Example
+//
+// Copy the configuration of a staging instance to production
+//
+//     snapshot, err := staging.Export()
+//     if err != nil {
+//         log.Fatal(err)
+//     }
+//     plan, err := production.Import(&nexus.ImportInput{
+//         Snapshot:     snapshot,
+//         UserPassword: nexus.String(os.Getenv("INITIAL_PASSWORD")),
+//     })
+//     if err != nil {
+//         log.Fatal(err)
+//     }
+//     fmt.Print(plan)
+func (n *Nexus) Import(input *ImportInput) (plan *ReconcilePlan, err error) {
+	return n.ImportWithContext(context.Background(), input)
+}
+
+// ImportWithContext is the same as Import with the addition of a context
+func (n *Nexus) ImportWithContext(ctx context.Context, input *ImportInput) (plan *ReconcilePlan, err error) {
+	if input == nil || input.Snapshot == nil {
+		err = errors.New("Snapshot is required for Import")
+		return
+	}
+	snapshot := input.Snapshot
+	plan = &ReconcilePlan{Changes: make([]*PlanChange, 0)}
+	// Content selectors and routing rules are referenced by repositories and
+	// privileges, privileges refer to scripts and repositories, and roles refer
+	// to privileges.
+	for _, step := range []func(context.Context, *Snapshot) ([]*PlanChange, error){
+		n.planImportContentSelectors,
+		n.planImportRoutingRules,
+		n.planImportScripts,
+		n.planImportDesiredState,
+		n.planImportPrivileges,
+		n.planImportRoles,
+	} {
+		var changes []*PlanChange
+		if changes, err = step(ctx, snapshot); err != nil {
+			return
+		}
+		plan.Changes = append(plan.Changes, changes...)
+	}
+	changes, err := n.planImportUsers(ctx, snapshot, input.UserPassword)
+	if err != nil {
+		return
+	}
+	plan.Changes = append(plan.Changes, changes...)
+	if input.DryRun != nil && *input.DryRun {
+		return
+	}
+	err = n.ApplyPlanWithContext(ctx, plan)
+	return
+}
+
+// planUpsert returns a change that creates an object when it does not exist or
+// updates it when the fields set in desired differ from current. It returns nil
+// when the object is up to date.
+func planUpsert(kind, name string, desired, current interface{}, exists bool, create, update func(context.Context) error) (change *PlanChange, err error) {
+	if !exists {
+		change = &PlanChange{Action: PlanActionCreate, Kind: kind, Name: name, apply: create}
+		return
+	}
+	diff, err := diffFields(desired, current)
+	if err != nil || len(diff) == 0 {
+		return
+	}
+	change = &PlanChange{Action: PlanActionUpdate, Kind: kind, Name: name, Diff: diff, apply: update}
+	return
+}
+
+func appendChange(changes []*PlanChange, change *PlanChange) []*PlanChange {
+	if change != nil {
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func (n *Nexus) planImportDesiredState(ctx context.Context, snapshot *Snapshot) (changes []*PlanChange, err error) {
+	plan, err := n.PlanWithContext(ctx, &ReconcileInput{Desired: &snapshot.DesiredState})
+	if err != nil {
+		return
+	}
+	changes = plan.Changes
+	return
+}
+
+func (n *Nexus) planImportContentSelectors(ctx context.Context, snapshot *Snapshot) (changes []*PlanChange, err error) {
+	if len(snapshot.ContentSelectors) == 0 {
+		return
+	}
+	existing, err := n.ListContentSelectorsWithContext(ctx)
+	if err != nil {
+		return
+	}
+	current := make(map[string]*ContentSelector)
+	for _, selector := range existing {
+		current[stringValue(selector.Name)] = selector
+	}
+	for _, selector := range snapshot.ContentSelectors {
+		selector := selector
+		name := stringValue(selector.Name)
+		var change *PlanChange
+		change, err = planUpsert(PlanKindContentSelector, name, selector, current[name], current[name] != nil,
+			func(ctx context.Context) error { return n.CreateContentSelectorWithContext(ctx, selector) },
+			func(ctx context.Context) error { return n.UpdateContentSelectorWithContext(ctx, selector) })
+		if err != nil {
+			return
+		}
+		changes = appendChange(changes, change)
+	}
+	return
+}
+
+func (n *Nexus) planImportRoutingRules(ctx context.Context, snapshot *Snapshot) (changes []*PlanChange, err error) {
+	if len(snapshot.RoutingRules) == 0 {
+		return
+	}
+	existing, err := n.ListRoutingRulesWithContext(ctx)
+	if err != nil {
+		return
+	}
+	current := make(map[string]*RoutingRule)
+	for _, rule := range existing {
+		current[stringValue(rule.Name)] = rule
+	}
+	for _, rule := range snapshot.RoutingRules {
+		rule := rule
+		name := stringValue(rule.Name)
+		var change *PlanChange
+		change, err = planUpsert(PlanKindRoutingRule, name, rule, current[name], current[name] != nil,
+			func(ctx context.Context) error { return n.CreateRoutingRuleWithContext(ctx, rule) },
+			func(ctx context.Context) error { return n.UpdateRoutingRuleWithContext(ctx, rule) })
+		if err != nil {
+			return
+		}
+		changes = appendChange(changes, change)
+	}
+	return
+}
+
+func (n *Nexus) planImportScripts(ctx context.Context, snapshot *Snapshot) (changes []*PlanChange, err error) {
+	if len(snapshot.Scripts) == 0 {
+		return
+	}
+	existing, err := n.ListScriptsWithContext(ctx)
+	if err != nil {
+		return
+	}
+	current := make(map[string]*Script)
+	for _, script := range existing.Scripts {
+		current[stringValue(script.Name)] = script
+	}
+	for _, script := range snapshot.Scripts {
+		script := script
+		name := stringValue(script.Name)
+		var change *PlanChange
+		change, err = planUpsert(PlanKindScript, name, script, current[name], current[name] != nil,
+			func(ctx context.Context) error {
+				_, err := n.CreateScriptWithContext(ctx, script)
+				return err
+			},
+			func(ctx context.Context) error {
+				_, err := n.UpdateScriptWithContext(ctx, script)
+				return err
+			})
+		if err != nil {
+			return
+		}
+		changes = appendChange(changes, change)
+	}
+	return
+}
+
+func (n *Nexus) planImportPrivileges(ctx context.Context, snapshot *Snapshot) (changes []*PlanChange, err error) {
+	if len(snapshot.Privileges) == 0 {
+		return
+	}
+	existing, err := n.ListPrivilegesWithContext(ctx)
+	if err != nil {
+		return
+	}
+	current := make(map[string]*Privilege)
+	for _, privilege := range existing {
+		current[stringValue(privilege.Name)] = privilege
+	}
+	for _, privilege := range snapshot.Privileges {
+		name := stringValue(privilege.Name)
+		var input PrivilegeInput
+		if input, err = privilege.privilegeInput(); err != nil {
+			return
+		}
+		var change *PlanChange
+		change, err = planUpsert(PlanKindPrivilege, name, privilege, current[name], current[name] != nil,
+			func(ctx context.Context) error { return n.CreatePrivilegeWithContext(ctx, input) },
+			func(ctx context.Context) error { return n.UpdatePrivilegeWithContext(ctx, input) })
+		if err != nil {
+			return
+		}
+		changes = appendChange(changes, change)
+	}
+	return
+}
+
+func (n *Nexus) planImportRoles(ctx context.Context, snapshot *Snapshot) (changes []*PlanChange, err error) {
+	if len(snapshot.Roles) == 0 {
+		return
+	}
+	existing, err := n.ListRolesWithContext(ctx, &ListRolesInput{Source: UserSourceDefault})
+	if err != nil {
+		return
+	}
+	current := make(map[string]*Role)
+	for _, role := range existing {
+		current[stringValue(role.ID)] = role
+	}
+	for _, role := range snapshot.Roles {
+		role := role
+		id := stringValue(role.ID)
+		var change *PlanChange
+		change, err = planUpsert(PlanKindRole, id, role, current[id], current[id] != nil,
+			func(ctx context.Context) error {
+				_, err := n.CreateRoleWithContext(ctx, role)
+				return err
+			},
+			func(ctx context.Context) error { return n.UpdateRoleWithContext(ctx, role) })
+		if err != nil {
+			return
+		}
+		changes = appendChange(changes, change)
+	}
+	return
+}
+
+func (n *Nexus) planImportUsers(ctx context.Context, snapshot *Snapshot, password *string) (changes []*PlanChange, err error) {
+	if len(snapshot.Users) == 0 {
+		return
+	}
+	existing, err := n.ListUsersWithContext(ctx, nil)
+	if err != nil {
+		return
+	}
+	current := make(map[string]*User)
+	for _, user := range existing {
+		current[stringValue(user.UserID)] = user
+	}
+	for _, user := range snapshot.Users {
+		user := user
+		id := stringValue(user.UserID)
+		external := user.Source != nil && *user.Source != "default"
+		if current[id] == nil {
+			if external {
+				continue
+			}
+			if password == nil {
+				err = fmt.Errorf("UserPassword is required to create user %s", id)
+				return
+			}
+		}
+		var change *PlanChange
+		change, err = planUpsert(PlanKindUser, id, user, current[id], current[id] != nil,
+			func(ctx context.Context) error {
+				create := *user
+				create.Password = password
+				_, err := n.CreateUserWithContext(ctx, &create)
+				return err
+			},
+			func(ctx context.Context) error { return n.UpdateUserWithContext(ctx, user) })
+		if err != nil {
+			return
+		}
+		changes = appendChange(changes, change)
+	}
+	return
+}
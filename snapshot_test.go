@@ -0,0 +1,79 @@
+package nexus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestImportCreatesPrivilegesBeforeRoles(t *testing.T) {
+	var writes []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			writes = append(writes, r.Method+" "+r.URL.Path)
+		}
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/service/rest/v1/security/roles":
+			w.Write([]byte(`{"id":"deployer","name":"deployer","privileges":["deploy-releases"]}`))
+		case r.Method == "POST":
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer srv.Close()
+	client, err := NewWithOptions(srv.URL, WithoutStatusCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := client.Import(&ImportInput{
+		Snapshot: &Snapshot{
+			Privileges: []*Privilege{{
+				Type:    PrivilegeTypeWildcard,
+				Name:    String("deploy-releases"),
+				Pattern: String("nexus:repository-view:maven2:maven-releases:add"),
+			}},
+			Roles: []*Role{{
+				ID:         String("deployer"),
+				Name:       String("deployer"),
+				Privileges: []string{"deploy-releases"},
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Changes) != 2 || plan.Changes[0].Kind != PlanKindPrivilege || plan.Changes[1].Kind != PlanKindRole {
+		t.Fatalf("Unexpected plan:\n%s", plan)
+	}
+	expected := []string{
+		"POST /service/rest/v1/security/privileges/wildcard",
+		"POST /service/rest/v1/security/roles",
+	}
+	if len(writes) != len(expected) || writes[0] != expected[0] || writes[1] != expected[1] {
+		t.Errorf("Expected requests %v, got %v", expected, writes)
+	}
+}
+
+func TestPrivilegeInput(t *testing.T) {
+	for _, privilegeType := range []*string{
+		PrivilegeTypeApplication,
+		PrivilegeTypeWildcard,
+		PrivilegeTypeRepositoryView,
+		PrivilegeTypeRepositoryAdmin,
+		PrivilegeTypeRepositoryContentSelector,
+		PrivilegeTypeScript,
+	} {
+		privilege := &Privilege{Type: privilegeType, Name: String("custom")}
+		input, err := privilege.privilegeInput()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if input.privilegeType() != *privilegeType || *input.privilegeName() != "custom" {
+			t.Errorf("Unexpected input %s %s for %s", input.privilegeType(), *input.privilegeName(), *privilegeType)
+		}
+	}
+	if _, err := (&Privilege{Type: String("unknown"), Name: String("custom")}).privilegeInput(); err == nil {
+		t.Error("Expected an error for an unknown privilege type")
+	}
+}
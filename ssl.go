@@ -0,0 +1,195 @@
+package nexus
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SSLCertificate represents a certificate in the Nexus truststore or presented by
+// a remote host. IssuedOn and ExpiresOn are in milliseconds since the epoch. Use
+// X509 to inspect the certificate with the crypto/x509 package.
+type SSLCertificate struct {
+	ID                        *string `json:"id"`
+	Fingerprint               *string `json:"fingerprint"`
+	SerialNumber              *string `json:"serialNumber"`
+	PEM                       *string `json:"pem"`
+	SubjectCommonName         *string `json:"subjectCommonName,omitempty"`
+	SubjectOrganization       *string `json:"subjectOrganization,omitempty"`
+	SubjectOrganizationalUnit *string `json:"subjectOrganizationalUnit,omitempty"`
+	IssuerCommonName          *string `json:"issuerCommonName,omitempty"`
+	IssuerOrganization        *string `json:"issuerOrganization,omitempty"`
+	IssuerOrganizationalUnit  *string `json:"issuerOrganizationalUnit,omitempty"`
+	IssuedOn                  *int64  `json:"issuedOn,omitempty"`
+	ExpiresOn                 *int64  `json:"expiresOn,omitempty"`
+}
+
+// X509 parses the PEM of this certificate
+func (c *SSLCertificate) X509() (*x509.Certificate, error) {
+	if c.PEM == nil {
+		return nil, errors.New("Certificate does not contain a PEM")
+	}
+	return parsePEMCertificate([]byte(*c.PEM))
+}
+
+// ExpiresWithin returns whether the certificate expires within the given duration
+// from now. Certificates without an expiry date never expire.
+func (c *SSLCertificate) ExpiresWithin(d time.Duration) bool {
+	if c.ExpiresOn == nil {
+		return false
+	}
+	expiry := time.Unix(0, *c.ExpiresOn*int64(time.Millisecond))
+	return time.Now().Add(d).After(expiry)
+}
+
+func parsePEMCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("No PEM encoded certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// GetRemoteCertificate retrieves the certificate presented by the given host and
+// port, as seen from the Nexus server. A port of 0 uses 443.
+func (n *Nexus) GetRemoteCertificate(host string, port int) (res *SSLCertificate, err error) {
+	return n.GetRemoteCertificateWithContext(context.Background(), host, port)
+}
+
+// GetRemoteCertificateWithContext is the same as GetRemoteCertificate with the addition of a context
+func (n *Nexus) GetRemoteCertificateWithContext(ctx context.Context, host string, port int) (res *SSLCertificate, err error) {
+	if port == 0 {
+		port = 443
+	}
+	args := map[string]string{
+		"host": host,
+		"port": strconv.Itoa(port),
+	}
+	req, err := n.NewRequestWithContext(ctx, "GET", "service/rest/v1/security/ssl", args, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		400: fmt.Sprintf("Could not retrieve the certificate of %s:%d", host, port),
+		403: "Insufficient permissions to retrieve remote certificates",
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// ListTrustedCertificates returns the certificates in the Nexus truststore
+func (n *Nexus) ListTrustedCertificates() (res []*SSLCertificate, err error) {
+	return n.ListTrustedCertificatesWithContext(context.Background())
+}
+
+// ListTrustedCertificatesWithContext is the same as ListTrustedCertificates with the addition of a context
+func (n *Nexus) ListTrustedCertificatesWithContext(ctx context.Context) (res []*SSLCertificate, err error) {
+	res = make([]*SSLCertificate, 0)
+	req, err := n.NewRequestWithContext(ctx, "GET", "service/rest/v1/security/ssl/truststore", nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: "Insufficient permissions to list trusted certificates",
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// AddTrustedCertificate adds a PEM encoded certificate to the Nexus truststore. An
+// error matching ErrConflict is returned when the certificate is already trusted.
+func (n *Nexus) AddTrustedCertificate(pemData string) (res *SSLCertificate, err error) {
+	return n.AddTrustedCertificateWithContext(context.Background(), pemData)
+}
+
+// AddTrustedCertificateWithContext is the same as AddTrustedCertificate with the addition of a context
+func (n *Nexus) AddTrustedCertificateWithContext(ctx context.Context, pemData string) (res *SSLCertificate, err error) {
+	if _, err = parsePEMCertificate([]byte(pemData)); err != nil {
+		return
+	}
+	req, err := n.NewRequestWithContext(ctx, "POST", "service/rest/v1/security/ssl/truststore", nil, []byte(pemData), "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: "Insufficient permissions to add trusted certificates",
+		409: "Certificate is already trusted",
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// TrustRemoteCertificate retrieves the certificate presented by the given host and
+// port and adds it to the Nexus truststore. This is useful when the certificate of
+// an upstream of a proxy repository has been rotated. A port of 0 uses 443.
+//
+// Example
+//
+// Trust the current certificate of a vendor repository unless it is already trusted
+//
+//     cert, err := client.GetRemoteCertificate("repo.vendor.example.com", 443)
+//     if err != nil {
+//         log.Fatal(err)
+//     }
+//     trusted, err := client.ListTrustedCertificates()
+//     if err != nil {
+//         log.Fatal(err)
+//     }
+//     for _, t := range trusted {
+//         if *t.Fingerprint == *cert.Fingerprint {
+//             return
+//         }
+//     }
+//     if _, err := client.TrustRemoteCertificate("repo.vendor.example.com", 443); err != nil {
+//         log.Fatal(err)
+//     }
+func (n *Nexus) TrustRemoteCertificate(host string, port int) (res *SSLCertificate, err error) {
+	return n.TrustRemoteCertificateWithContext(context.Background(), host, port)
+}
+
+// TrustRemoteCertificateWithContext is the same as TrustRemoteCertificate with the addition of a context
+func (n *Nexus) TrustRemoteCertificateWithContext(ctx context.Context, host string, port int) (res *SSLCertificate, err error) {
+	cert, err := n.GetRemoteCertificateWithContext(ctx, host, port)
+	if err != nil {
+		return
+	}
+	if cert.PEM == nil {
+		err = fmt.Errorf("No certificate was returned for %s", host)
+		return
+	}
+	res, err = n.AddTrustedCertificateWithContext(ctx, *cert.PEM)
+	return
+}
+
+// RemoveTrustedCertificate removes the certificate with the given ID from the Nexus truststore
+func (n *Nexus) RemoveTrustedCertificate(id string) (err error) {
+	return n.RemoveTrustedCertificateWithContext(context.Background(), id)
+}
+
+// RemoveTrustedCertificateWithContext is the same as RemoveTrustedCertificate with the addition of a context
+func (n *Nexus) RemoveTrustedCertificateWithContext(ctx context.Context, id string) (err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/security/ssl/truststore/%s", id)
+	req, err := n.NewRequestWithContext(ctx, "DELETE", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: "Insufficient permissions to remove trusted certificates",
+		404: fmt.Sprintf("Certificate %s is not trusted", id),
+	}, false)
+	return
+}
@@ -0,0 +1,263 @@
+package nexus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// TaskTypeCompactBlobStore and friends are the types of some commonly used tasks,
+// for use with ListTasksInput.Type.
+var (
+	TaskTypeCompactBlobStore        = String("blobstore.compact")
+	TaskTypeRebuildMavenMetadata    = String("repository.maven.rebuild-metadata")
+	TaskTypeRebuildRepositoryIndex  = String("repository.rebuild-index")
+	TaskTypeRepositoryCleanup       = String("repository.cleanup")
+	TaskTypeDockerGarbageCollection = String("repository.docker.gc")
+	TaskTypeDockerUploadPurge       = String("repository.docker.upload-purge")
+	TaskTypeDatabaseBackup          = String("db.backup")
+	TaskTypeExecuteScript           = String("script")
+	TaskTypePurgeUnusedComponents   = String("repository.purge-unused")
+	TaskTypeRebuildBrowseTree       = String("create.browse.nodes")
+)
+
+// TaskStateWaiting and friends are the values of Task.CurrentState. A task that
+// is not running is waiting for its next run, or done if it will never run again.
+const (
+	TaskStateWaiting = "WAITING"
+	TaskStateRunning = "RUNNING"
+	TaskStateDone    = "DONE"
+)
+
+// TaskResultOK and friends are the values of Task.LastRunResult
+const (
+	TaskResultOK          = "OK"
+	TaskResultFailed      = "FAILED"
+	TaskResultCanceled    = "CANCELED"
+	TaskResultInterrupted = "INTERRUPTED"
+)
+
+// taskPollInterval is how often WaitForTask checks the state of a task
+var taskPollInterval = 2 * time.Second
+
+// Task represents a scheduled task. LastRunResult is nil until the task has run once.
+type Task struct {
+	ID            *string    `json:"id"`
+	Name          *string    `json:"name"`
+	Type          *string    `json:"type"`
+	Message       *string    `json:"message,omitempty"`
+	CurrentState  *string    `json:"currentState"`
+	LastRunResult *string    `json:"lastRunResult,omitempty"`
+	NextRun       *time.Time `json:"nextRun,omitempty"`
+	LastRun       *time.Time `json:"lastRun,omitempty"`
+}
+
+// ListTasksInput provides an optional filter to ListTasks
+type ListTasksInput struct {
+	Type *string
+}
+
+type listTasksResponse struct {
+	Items             []*Task `json:"items"`
+	ContinuationToken *string `json:"continuationToken"`
+}
+
+// ListTasks returns the scheduled tasks in Nexus. The input may be nil to list all tasks.
+func (n *Nexus) ListTasks(input *ListTasksInput) (res []*Task, err error) {
+	return n.ListTasksWithContext(context.Background(), input)
+}
+
+// ListTasksWithContext is the same as ListTasks with the addition of a context
+func (n *Nexus) ListTasksWithContext(ctx context.Context, input *ListTasksInput) (res []*Task, err error) {
+	res = make([]*Task, 0)
+	args := make(map[string]string)
+	if input != nil && input.Type != nil {
+		args["type"] = *input.Type
+	}
+	for {
+		var req *http.Request
+		if req, err = n.NewRequestWithContext(ctx, "GET", "service/rest/v1/tasks", args, nil, ""); err != nil {
+			return
+		}
+		var body []byte
+		body, err = n.Do(req, map[int]string{
+			403: "Insufficient permissions to list tasks",
+		}, false)
+		if err != nil {
+			return
+		}
+		var page listTasksResponse
+		if err = json.Unmarshal(body, &page); err != nil {
+			return
+		}
+		res = append(res, page.Items...)
+		if page.ContinuationToken == nil {
+			return
+		}
+		args["continuationToken"] = *page.ContinuationToken
+	}
+}
+
+// GetTask retrieves a task by ID
+func (n *Nexus) GetTask(id string) (res *Task, err error) {
+	return n.GetTaskWithContext(context.Background(), id)
+}
+
+// GetTaskWithContext is the same as GetTask with the addition of a context
+func (n *Nexus) GetTaskWithContext(ctx context.Context, id string) (res *Task, err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/tasks/%s", id)
+	req, err := n.NewRequestWithContext(ctx, "GET", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to get task %s", id),
+		404: fmt.Sprintf("Task %s does not exist", id),
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// RunTask starts the task with the given ID. It returns once the task has been
+// started, use WaitForTask or RunTaskAndWait to wait for it to finish.
+func (n *Nexus) RunTask(id string) (err error) {
+	return n.RunTaskWithContext(context.Background(), id)
+}
+
+// RunTaskWithContext is the same as RunTask with the addition of a context
+func (n *Nexus) RunTaskWithContext(ctx context.Context, id string) (err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/tasks/%s/run", id)
+	req, err := n.NewRequestWithContext(ctx, "POST", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to run task %s", id),
+		404: fmt.Sprintf("Task %s does not exist", id),
+		405: fmt.Sprintf("Task %s is disabled", id),
+	}, false)
+	return
+}
+
+// StopTask stops the task with the given ID if it is running
+func (n *Nexus) StopTask(id string) (err error) {
+	return n.StopTaskWithContext(context.Background(), id)
+}
+
+// StopTaskWithContext is the same as StopTask with the addition of a context
+func (n *Nexus) StopTaskWithContext(ctx context.Context, id string) (err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/tasks/%s/stop", id)
+	req, err := n.NewRequestWithContext(ctx, "POST", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to stop task %s", id),
+		404: fmt.Sprintf("Task %s does not exist", id),
+		409: fmt.Sprintf("Task %s could not be stopped", id),
+	}, false)
+	return
+}
+
+// WaitForTask polls the task with the given ID until it is no longer running and
+// returns its final state. An error is returned along with the task when its last
+// run did not succeed, or when the timeout expires first. A timeout of 0 waits
+// until the task finishes. Since a task may not have started yet right after
+// RunTask, use RunTaskAndWait to start a task and wait for that run to finish.
+func (n *Nexus) WaitForTask(id string, timeout time.Duration) (res *Task, err error) {
+	return n.WaitForTaskWithContext(context.Background(), id, timeout)
+}
+
+// WaitForTaskWithContext is the same as WaitForTask with the addition of a context
+func (n *Nexus) WaitForTaskWithContext(ctx context.Context, id string, timeout time.Duration) (res *Task, err error) {
+	return n.waitForTask(ctx, id, timeout, nil)
+}
+
+// RunTaskAndWait starts the task with the given ID and waits for the run to finish
+// as described by WaitForTask.
+//
+// Example
+//
+// Rebuild the metadata of every maven repository after a deployment
+//
+//     tasks, err := client.ListTasks(&nexus.ListTasksInput{
+//         Type: nexus.TaskTypeRebuildMavenMetadata,
+//     })
+//     if err != nil {
+//         log.Fatal(err)
+//     }
+//     for _, task := range tasks {
+//         if _, err := client.RunTaskAndWait(*task.ID, 30*time.Minute); err != nil {
+//             log.Fatal(err)
+//         }
+//     }
+func (n *Nexus) RunTaskAndWait(id string, timeout time.Duration) (res *Task, err error) {
+	return n.RunTaskAndWaitWithContext(context.Background(), id, timeout)
+}
+
+// RunTaskAndWaitWithContext is the same as RunTaskAndWait with the addition of a context
+func (n *Nexus) RunTaskAndWaitWithContext(ctx context.Context, id string, timeout time.Duration) (res *Task, err error) {
+	before, err := n.GetTaskWithContext(ctx, id)
+	if err != nil {
+		return
+	}
+	if err = n.RunTaskWithContext(ctx, id); err != nil {
+		return
+	}
+	return n.waitForTask(ctx, id, timeout, before)
+}
+
+// waitForTask polls a task until it is not running. When before is given, the
+// task is only considered finished once it has been seen running or its last run
+// has changed, so that a run that has not started yet is not mistaken for one that
+// has finished.
+func (n *Nexus) waitForTask(ctx context.Context, id string, timeout time.Duration, before *Task) (res *Task, err error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	started := before == nil
+	for {
+		// res keeps the last known state of the task if the request fails
+		var task *Task
+		task, err = n.GetTaskWithContext(ctx, id)
+		if err != nil {
+			if ctx.Err() != nil {
+				err = fmt.Errorf("Stopped waiting for task %s: %w", id, ctx.Err())
+			}
+			return
+		}
+		res = task
+		running := res.CurrentState != nil && *res.CurrentState == TaskStateRunning
+		if running || (!started && !sameTime(before.LastRun, res.LastRun)) {
+			started = true
+		}
+		if started && !running {
+			if res.LastRunResult != nil && *res.LastRunResult != TaskResultOK {
+				err = fmt.Errorf("Task %s finished with result %s", id, *res.LastRunResult)
+			}
+			return
+		}
+		timer := time.NewTimer(taskPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			err = fmt.Errorf("Stopped waiting for task %s: %w", id, ctx.Err())
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
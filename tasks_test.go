@@ -0,0 +1,66 @@
+package nexus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListTasksFollowsContinuationTokens(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("type") != "repository.cleanup" {
+			t.Errorf("Expected the type filter, got %s", r.URL.RawQuery)
+		}
+		switch r.URL.Query().Get("continuationToken") {
+		case "":
+			w.Write([]byte(`{"items":[{"id":"1"}],"continuationToken":"next"}`))
+		case "next":
+			w.Write([]byte(`{"items":[{"id":"2"}],"continuationToken":null}`))
+		}
+	}))
+	defer srv.Close()
+	client, err := NewWithOptions(srv.URL, WithoutStatusCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tasks, err := client.ListTasks(&ListTasksInput{Type: TaskTypeRepositoryCleanup})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 2 || *tasks[0].ID != "1" || *tasks[1].ID != "2" {
+		t.Errorf("Unexpected tasks %v", tasks)
+	}
+}
+
+func TestWaitForTaskReturnsLastKnownTaskOnTimeout(t *testing.T) {
+	defer func(interval time.Duration) { taskPollInterval = interval }(taskPollInterval)
+	taskPollInterval = 10 * time.Millisecond
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests > 1 {
+			// Keep the request in flight until the wait times out
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		w.Write([]byte(`{"id":"1","currentState":"RUNNING"}`))
+	}))
+	defer srv.Close()
+	client, err := NewWithOptions(srv.URL, WithoutStatusCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := client.WaitForTask("1", 100*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the wait to time out, got %v", err)
+	}
+	if task == nil || stringValue(task.CurrentState) != TaskStateRunning {
+		t.Errorf("Expected the last known state of the task, got %+v", task)
+	}
+}
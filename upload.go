@@ -0,0 +1,202 @@
+package nexus
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"sync"
+)
+
+// UploadProgressFunc is called as the content of an asset is sent to Nexus with
+// the asset's filename, the number of bytes sent so far, and the total size of
+// the asset, or -1 when the size is not known. When an upload is retried the
+// count starts over from zero.
+type UploadProgressFunc func(filename string, sent int64, total int64)
+
+// uploadBody streams the multipart form for a component upload through a pipe,
+// so the content of the assets is never held in memory.
+type uploadBody struct {
+	input    *UploadComponentInput
+	boundary string
+	// offsets are the positions each asset's content started at, used to rewind
+	// them when the request is replayed.
+	offsets []int64
+
+	mu sync.Mutex
+	// reader and done belong to the most recently opened body. done is closed
+	// once the goroutine writing it has stopped reading the assets.
+	reader *io.PipeReader
+	done   chan struct{}
+}
+
+// errUploadReplayed stops the writer of a body that has been replaced by a replay.
+var errUploadReplayed = errors.New("Upload body was replayed")
+
+// newUploadBody prepares the body for the given input. The boundary is generated
+// once so every replay of the request matches the Content-Type header.
+func newUploadBody(input *UploadComponentInput) (body *uploadBody, err error) {
+	body = &uploadBody{
+		input:    input,
+		boundary: multipart.NewWriter(nil).Boundary(),
+		offsets:  make([]int64, len(input.Assets)),
+	}
+	for idx, asset := range input.Assets {
+		seeker, ok := asset.content().(io.Seeker)
+		if !ok {
+			body.offsets = nil
+			break
+		}
+		body.offsets[idx], err = seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+func (b *uploadBody) contentType() string {
+	return "multipart/form-data; boundary=" + b.boundary
+}
+
+// replayable reports whether the content of every asset can be rewound.
+func (b *uploadBody) replayable() bool {
+	return b.offsets != nil
+}
+
+// replay stops the previous body, rewinds the content of every asset and opens
+// a new body. The previous writer must have stopped before seeking, since it may
+// still be copying from the same readers.
+func (b *uploadBody) replay() (io.ReadCloser, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.reader != nil {
+		b.reader.CloseWithError(errUploadReplayed)
+		<-b.done
+	}
+	for idx, asset := range b.input.Assets {
+		if _, err := asset.content().(io.Seeker).Seek(b.offsets[idx], io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+	return b.openLocked(), nil
+}
+
+// open starts writing the form into a pipe and returns the reading end. Any error
+// reading the assets is returned from the reader.
+func (b *uploadBody) open() io.ReadCloser {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.openLocked()
+}
+
+func (b *uploadBody) openLocked() io.ReadCloser {
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pw.CloseWithError(b.write(pw))
+	}()
+	b.reader = pr
+	b.done = done
+	return pr
+}
+
+func (b *uploadBody) write(w io.Writer) (err error) {
+	input := b.input
+	writer := multipart.NewWriter(w)
+	if err = writer.SetBoundary(b.boundary); err != nil {
+		return
+	}
+	if input.ComponentConfig != nil {
+		for k, v := range *input.ComponentConfig {
+			key := fmt.Sprintf("%s.%s", *input.ComponentType, k)
+			if err = writer.WriteField(key, v); err != nil {
+				return
+			}
+		}
+	}
+	for idx, asset := range input.Assets {
+		prefix := fmt.Sprintf("%s.asset%v", *input.ComponentType, idx)
+		if len(input.Assets) == 1 {
+			prefix = fmt.Sprintf("%s.asset", *input.ComponentType)
+		}
+		if err = b.writeAsset(writer, prefix, asset); err != nil {
+			return
+		}
+	}
+	return writer.Close()
+}
+
+func (b *uploadBody) writeAsset(writer *multipart.Writer, prefix string, asset *UploadComponentAsset) (err error) {
+	filename := asset.filename()
+	part, err := writer.CreateFormFile(prefix, filename)
+	if err != nil {
+		return
+	}
+	var content io.Reader = asset.content()
+	if b.input.OnProgress != nil {
+		content = &progressReader{
+			reader:   content,
+			filename: filename,
+			total:    asset.size(),
+			callback: b.input.OnProgress,
+		}
+	}
+	if _, err = io.Copy(part, content); err != nil {
+		return
+	}
+	if asset.AssetConfig != nil {
+		for k, v := range *asset.AssetConfig {
+			key := fmt.Sprintf("%s.%s", prefix, k)
+			if err = writer.WriteField(key, v); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// content returns the reader the asset is uploaded from.
+func (a *UploadComponentAsset) content() io.Reader {
+	if a.Reader != nil {
+		return a.Reader
+	}
+	return a.File
+}
+
+func (a *UploadComponentAsset) filename() string {
+	if a.Filename != nil {
+		return *a.Filename
+	}
+	return filepath.Base(a.File.Name())
+}
+
+// size returns the size of the asset when it is read from a File, or -1.
+func (a *UploadComponentAsset) size() int64 {
+	if a.Reader == nil && a.File != nil {
+		if info, err := a.File.Stat(); err == nil {
+			return info.Size()
+		}
+	}
+	return -1
+}
+
+// progressReader reports the number of bytes read through it.
+type progressReader struct {
+	reader   io.Reader
+	filename string
+	sent     int64
+	total    int64
+	callback UploadProgressFunc
+}
+
+func (r *progressReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	if n > 0 {
+		r.sent += int64(n)
+		r.callback(r.filename, r.sent, r.total)
+	}
+	return
+}
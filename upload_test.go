@@ -0,0 +1,102 @@
+package nexus
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+)
+
+// readUploadForm returns the content of each part of an upload body by form name
+func readUploadForm(t *testing.T, body *uploadBody, r io.Reader) map[string]string {
+	_, params, err := mime.ParseMediaType(body.contentType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	form := make(map[string]string)
+	reader := multipart.NewReader(r, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return form
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := ioutil.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		form[part.FormName()] = string(content)
+	}
+}
+
+func TestUploadBodyReplayAfterPartialRead(t *testing.T) {
+	large := strings.Repeat("0123456789abcdef", 8192)
+	for _, partial := range []int{0, 1, 100, 70000, 200000} {
+		first := strings.NewReader("skipped-hello")
+		// Content is uploaded from the current position of the reader
+		if _, err := first.Seek(int64(len("skipped-")), io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		input := &UploadComponentInput{
+			ComponentType:   String("raw"),
+			ComponentConfig: &map[string]string{"directory": "/files"},
+			Assets: []*UploadComponentAsset{
+				{Reader: first, Filename: String("hello.txt")},
+				{Reader: bytes.NewReader([]byte(large)), Filename: String("large.bin")},
+			},
+		}
+		body, err := newUploadBody(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !body.replayable() {
+			t.Fatal("Expected the body to be replayable")
+		}
+		r := body.open()
+		// The last case reads past the end of the body, as a failed request would
+		io.ReadFull(r, make([]byte, partial))
+		replayed, err := body.replay()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.Read(make([]byte, 1)); err == nil {
+			t.Error("Expected the previous body to be closed")
+		}
+		form := readUploadForm(t, body, replayed)
+		replayed.Close()
+		for name, expected := range map[string]string{
+			"raw.directory": "/files",
+			"raw.asset0":    "hello",
+			"raw.asset1":    large,
+		} {
+			if form[name] != expected {
+				t.Errorf("Partial read of %d bytes: unexpected %s of %d bytes after replay", partial, name, len(form[name]))
+			}
+		}
+	}
+}
+
+func TestUploadBodyNotReplayable(t *testing.T) {
+	body, err := newUploadBody(&UploadComponentInput{
+		ComponentType: String("raw"),
+		Assets: []*UploadComponentAsset{
+			{Reader: strings.NewReader("hello"), Filename: String("hello.txt")},
+			{Reader: ioutil.NopCloser(strings.NewReader("world")), Filename: String("world.txt")},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body.replayable() {
+		t.Error("Expected a body with a reader that cannot seek not to be replayable")
+	}
+	form := readUploadForm(t, body, body.open())
+	if form["raw.asset0"] != "hello" || form["raw.asset1"] != "world" {
+		t.Errorf("Unexpected form %v", form)
+	}
+}
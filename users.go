@@ -0,0 +1,173 @@
+package nexus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// UserStatusActive and friends are used when specifying User.Status
+var (
+	UserStatusActive         = String("active")
+	UserStatusLocked         = String("locked")
+	UserStatusDisabled       = String("disabled")
+	UserStatusChangePassword = String("changepassword")
+)
+
+// UserSourceDefault is the source of users managed by Nexus itself
+var UserSourceDefault = String("default")
+
+// User represents a user known to Nexus. Users with a Source other than
+// "default" come from an external realm such as LDAP, and only their Roles can be changed.
+// Password is only used when creating a user and is never returned.
+type User struct {
+	UserID        *string  `json:"userId"`
+	FirstName     *string  `json:"firstName,omitempty"`
+	LastName      *string  `json:"lastName,omitempty"`
+	EmailAddress  *string  `json:"emailAddress,omitempty"`
+	Password      *string  `json:"password,omitempty"`
+	Source        *string  `json:"source,omitempty"`
+	Status        *string  `json:"status,omitempty"`
+	ReadOnly      *bool    `json:"readOnly,omitempty"`
+	Roles         []string `json:"roles"`
+	ExternalRoles []string `json:"externalRoles,omitempty"`
+}
+
+// ListUsersInput provides optional filters to ListUsers. UserID matches users
+// whose ID starts with the given value, and Source limits the results to a single
+// realm such as UserSourceDefault or "LDAP". External sources only return a limited
+// number of users, so a UserID filter is recommended with them.
+type ListUsersInput struct {
+	UserID *string
+	Source *string
+}
+
+// ListUsers returns the users known to Nexus. The input may be nil to list all users.
+func (n *Nexus) ListUsers(input *ListUsersInput) (res []*User, err error) {
+	return n.ListUsersWithContext(context.Background(), input)
+}
+
+// ListUsersWithContext is the same as ListUsers with the addition of a context
+func (n *Nexus) ListUsersWithContext(ctx context.Context, input *ListUsersInput) (res []*User, err error) {
+	res = make([]*User, 0)
+	args := make(map[string]string)
+	if input != nil {
+		if input.UserID != nil {
+			args["userId"] = *input.UserID
+		}
+		if input.Source != nil {
+			args["source"] = *input.Source
+		}
+	}
+	req, err := n.NewRequestWithContext(ctx, "GET", "service/rest/v1/security/users", args, nil, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: "Insufficient permissions to list users",
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// CreateUser creates a new user in the default realm. UserID, FirstName, LastName,
+// EmailAddress, Password, Status and Roles are required.
+func (n *Nexus) CreateUser(user *User) (res *User, err error) {
+	return n.CreateUserWithContext(context.Background(), user)
+}
+
+// CreateUserWithContext is the same as CreateUser with the addition of a context
+func (n *Nexus) CreateUserWithContext(ctx context.Context, user *User) (res *User, err error) {
+	if user.UserID == nil || user.Password == nil {
+		err = errors.New("UserID and Password are required for CreateUser")
+		return
+	}
+	payload, err := json.Marshal(user)
+	if err != nil {
+		return
+	}
+	req, err := n.NewRequestWithContext(ctx, "POST", "service/rest/v1/security/users", nil, payload, "")
+	if err != nil {
+		return
+	}
+	body, err := n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to create user %s", *user.UserID),
+	}, false)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &res)
+	return
+}
+
+// UpdateUser replaces the details of an existing user. The password is not changed.
+func (n *Nexus) UpdateUser(user *User) (err error) {
+	return n.UpdateUserWithContext(context.Background(), user)
+}
+
+// UpdateUserWithContext is the same as UpdateUser with the addition of a context
+func (n *Nexus) UpdateUserWithContext(ctx context.Context, user *User) (err error) {
+	if user.UserID == nil {
+		err = errors.New("UserID is required for UpdateUser")
+		return
+	}
+	payload := *user
+	payload.Password = nil
+	body, err := json.Marshal(&payload)
+	if err != nil {
+		return
+	}
+	endpoint := fmt.Sprintf("service/rest/v1/security/users/%s", *user.UserID)
+	req, err := n.NewRequestWithContext(ctx, "PUT", endpoint, nil, body, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to update user %s", *user.UserID),
+		404: fmt.Sprintf("User %s does not exist", *user.UserID),
+	}, false)
+	return
+}
+
+// DeleteUser removes the user with the given ID
+func (n *Nexus) DeleteUser(userID string) (err error) {
+	return n.DeleteUserWithContext(context.Background(), userID)
+}
+
+// DeleteUserWithContext is the same as DeleteUser with the addition of a context
+func (n *Nexus) DeleteUserWithContext(ctx context.Context, userID string) (err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/security/users/%s", userID)
+	req, err := n.NewRequestWithContext(ctx, "DELETE", endpoint, nil, nil, "")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		403: fmt.Sprintf("Insufficient permissions to delete user %s", userID),
+		404: fmt.Sprintf("User %s does not exist", userID),
+	}, false)
+	return
+}
+
+// ChangePassword sets the password of a user in the default source
+func (n *Nexus) ChangePassword(userID string, password string) (err error) {
+	return n.ChangePasswordWithContext(context.Background(), userID, password)
+}
+
+// ChangePasswordWithContext is the same as ChangePassword with the addition of a context
+func (n *Nexus) ChangePasswordWithContext(ctx context.Context, userID string, password string) (err error) {
+	endpoint := fmt.Sprintf("service/rest/v1/security/users/%s/change-password", userID)
+	req, err := n.NewRequestWithContext(ctx, "PUT", endpoint, nil, []byte(password), "text/plain")
+	if err != nil {
+		return
+	}
+	_, err = n.Do(req, map[int]string{
+		400: "Password was not supplied",
+		403: fmt.Sprintf("Insufficient permissions to change the password of user %s", userID),
+		404: fmt.Sprintf("User %s does not exist", userID),
+	}, false)
+	return
+}